@@ -1,10 +1,18 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
 	"github.com/Wilson1510/klampis-pim-go/internal/config"
 	"github.com/Wilson1510/klampis-pim-go/internal/database"
 	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/internal/router"
+	"github.com/Wilson1510/klampis-pim-go/internal/server"
+	"github.com/Wilson1510/klampis-pim-go/pkg/utils"
 )
 
 func main() {
@@ -13,17 +21,27 @@ func main() {
 		panic(err)
 	}
 
+	// Slugs are generated by model hooks, so configure them before anything is written
+	if err := configureSlugs(&config.Slug); err != nil {
+		panic(fmt.Sprintf("Failed to configure slugs: %v", err))
+	}
+	if err := configureDates(&config.Date); err != nil {
+		panic(fmt.Sprintf("Failed to configure date formats: %v", err))
+	}
+
 	db, err := database.NewConnection(&config.Database)
 	if err != nil {
 		panic(err)
 	}
 
-	sqlDB, err := db.DB()
+	// Values become unique per SKU and attribute, so drop duplicates before the index is created
+	removed, err := database.RemoveDuplicateAttributeValues(db)
 	if err != nil {
-		panic(err)
+		panic(fmt.Sprintf("Failed to remove duplicate attribute values: %v", err))
+	}
+	if len(removed) > 0 {
+		fmt.Printf("Soft-deleted %d duplicate attribute value(s): %v\n", len(removed), removed)
 	}
-
-	defer sqlDB.Close()
 
 	// Run database migrations
 	err = database.AutoMigrate(db)
@@ -34,14 +52,63 @@ func main() {
 	fmt.Println("Database connected successfully")
 	fmt.Println("Database migration completed successfully")
 
+	// Replace any plaintext passwords left from before hashing was introduced
+	rehashed, err := database.HashPlaintextPasswords(db)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to hash plaintext passwords: %v", err))
+	}
+	if rehashed > 0 {
+		fmt.Printf("Hashed %d plaintext password(s)\n", rehashed)
+	}
+
+	// Flag numbers that may have been rounded before they were stored losslessly
+	checked, flagged, err := database.FlagRoundedNumberValues(db)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to flag rounded number values: %v", err))
+	}
+	if checked > 0 {
+		fmt.Printf("Checked %d stored value(s), flagged %d possibly rounded number(s) for review\n", checked, flagged)
+	}
+
+	// Store dates entered as 31/12/2023 and similar in ISO form
+	canonicalized, err := database.CanonicalizeDateValues(db)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to canonicalize date values: %v", err))
+	}
+	if canonicalized > 0 {
+		fmt.Printf("Rewrote %d date value(s) in ISO form\n", canonicalized)
+	}
+
+	// Normalize numbers to the base unit of their dimension for comparing and filtering
+	normalized, err := database.BackfillBaseValues(db)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to backfill base values: %v", err))
+	}
+	if normalized > 0 {
+		fmt.Printf("Computed base values of %d attribute(s)\n", normalized)
+	}
+
+	// Compute category paths for rows created before paths were maintained
+	backfilled, err := database.BackfillCategoryPaths(db)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to backfill category paths: %v", err))
+	}
+	if backfilled > 0 {
+		fmt.Printf("Backfilled %d category path(s)\n", backfilled)
+	}
+
 	// Create or get system user for audit fields
 	var systemUser models.User
-	err = db.Where("username = ?", "system").First(&systemUser).Error
+	err = db.Where("username = ?", models.SystemUsername).First(&systemUser).Error
 	if err != nil {
-		// Create system user if not exists
+		// Create system user if not exists, with a password nobody knows
+		password, err := utils.RandomPassword()
+		if err != nil {
+			panic(fmt.Sprintf("Failed to generate system user password: %v", err))
+		}
 		systemUser = models.User{
-			Username: "system",
-			Password: "system123", // In production, use proper hashing
+			Username: models.SystemUsername,
+			Password: password, // Hashed by the User BeforeCreate hook
 			Name:     "System User",
 			Role:     models.RoleSystem,
 		}
@@ -53,4 +120,48 @@ func main() {
 	} else {
 		fmt.Println("System user already exists")
 	}
+
+	// Stop the server on SIGINT/SIGTERM so deploys drain in-flight requests
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := server.New(&config.App, router.New(config, db), db)
+	if err := srv.Run(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Server error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// configureSlugs applies the slug length and loads the extra transliteration tables
+func configureSlugs(cfg *config.SlugConfig) error {
+	options := utils.DefaultSlugOptions()
+	options.MaxLength = cfg.MaxLength
+
+	tables := make([]utils.Transliterator, 0, len(cfg.TransliterationTables))
+	for _, path := range cfg.TransliterationTables {
+		table, err := utils.LoadTransliterationTableFile(path)
+		if err != nil {
+			return err
+		}
+		tables = append(tables, table)
+	}
+	options.Transliterators = append(tables, options.Transliterators...)
+
+	utils.SetSlugOptions(options)
+	return nil
+}
+
+// configureDates adds the configured date locales to the built-in ones
+func configureDates(cfg *config.DateConfig) error {
+	configured, err := utils.ParseDateLocales(cfg.InputFormats)
+	if err != nil {
+		return err
+	}
+
+	locales := utils.DefaultDateLocales()
+	for locale, layouts := range configured {
+		locales[locale] = layouts
+	}
+	utils.SetDateLocales(locales)
+	return nil
 }
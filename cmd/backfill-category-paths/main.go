@@ -0,0 +1,39 @@
+// Command backfill-category-paths recomputes the materialized ID and slug paths
+// of every category from parent_id. The app does the same on start; this command
+// is for repairing paths after parent_id or slugs were changed outside the application.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/config"
+	"github.com/Wilson1510/klampis-pim-go/internal/database"
+)
+
+func main() {
+	config, err := config.LoadConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	db, err := database.NewConnection(&config.Database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to database: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Adds the path column when the app has not been started since it was introduced
+	if err := database.AutoMigrate(db); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to migrate database: %v\n", err)
+		os.Exit(1)
+	}
+
+	updated, err := database.BackfillCategoryPaths(db)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to backfill category paths: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Updated %d category path(s)\n", updated)
+}
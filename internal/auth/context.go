@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+)
+
+type contextKey int
+
+const (
+	userContextKey contextKey = iota
+	claimsContextKey
+)
+
+// WithUser returns a copy of ctx carrying the authenticated user and their token claims
+func WithUser(ctx context.Context, user *models.User, claims *AccessClaims) context.Context {
+	ctx = context.WithValue(ctx, userContextKey, user)
+	return context.WithValue(ctx, claimsContextKey, claims)
+}
+
+// UserFromContext returns the authenticated user stored in ctx, if any
+func UserFromContext(ctx context.Context) (*models.User, bool) {
+	user, ok := ctx.Value(userContextKey).(*models.User)
+	return user, ok && user != nil
+}
+
+// ClaimsFromContext returns the access token claims stored in ctx, if any
+func ClaimsFromContext(ctx context.Context) (*AccessClaims, bool) {
+	claims, ok := ctx.Value(claimsContextKey).(*AccessClaims)
+	return claims, ok && claims != nil
+}
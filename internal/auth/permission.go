@@ -0,0 +1,69 @@
+package auth
+
+import "github.com/Wilson1510/klampis-pim-go/internal/models"
+
+// Resource identifies a group of endpoints guarded by the permission matrix
+type Resource string
+
+const (
+	ResourceCategories Resource = "categories"
+	ResourceProducts   Resource = "products"
+	ResourceSkus       Resource = "skus"
+	ResourceAttributes Resource = "attributes"
+	ResourceImages     Resource = "images"
+	ResourceUsers      Resource = "users"
+)
+
+// Action is an operation performed on a resource
+type Action string
+
+const (
+	ActionRead   Action = "read"
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+var (
+	allActions    = []Action{ActionRead, ActionCreate, ActionUpdate, ActionDelete}
+	editorActions = []Action{ActionRead, ActionCreate, ActionUpdate}
+)
+
+// permissions maps each role to the actions it may perform per resource.
+// Resources missing from a role's entry are fully denied.
+var permissions = map[models.UserRole]map[Resource][]Action{
+	models.RoleSystem: {
+		ResourceCategories: allActions,
+		ResourceProducts:   allActions,
+		ResourceSkus:       allActions,
+		ResourceAttributes: allActions,
+		ResourceImages:     allActions,
+		ResourceUsers:      allActions,
+	},
+	models.RoleAdmin: {
+		ResourceCategories: allActions,
+		ResourceProducts:   allActions,
+		ResourceSkus:       allActions,
+		ResourceAttributes: allActions,
+		ResourceImages:     allActions,
+		ResourceUsers:      allActions,
+	},
+	// Plain users maintain catalog data but cannot delete it or manage users
+	models.RoleUser: {
+		ResourceCategories: editorActions,
+		ResourceProducts:   editorActions,
+		ResourceSkus:       editorActions,
+		ResourceAttributes: editorActions,
+		ResourceImages:     editorActions,
+	},
+}
+
+// Can reports whether role may perform action on resource
+func Can(role models.UserRole, resource Resource, action Action) bool {
+	for _, allowed := range permissions[role][resource] {
+		if allowed == action {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCan(t *testing.T) {
+	testCases := []struct {
+		name     string
+		role     models.UserRole
+		resource Resource
+		action   Action
+		expected bool
+	}{
+		{name: "System manages users", role: models.RoleSystem, resource: ResourceUsers, action: ActionDelete, expected: true},
+		{name: "Admin manages users", role: models.RoleAdmin, resource: ResourceUsers, action: ActionCreate, expected: true},
+		{name: "Admin deletes catalog data", role: models.RoleAdmin, resource: ResourceProducts, action: ActionDelete, expected: true},
+		{name: "User reads catalog data", role: models.RoleUser, resource: ResourceCategories, action: ActionRead, expected: true},
+		{name: "User creates catalog data", role: models.RoleUser, resource: ResourceSkus, action: ActionCreate, expected: true},
+		{name: "User edits catalog data", role: models.RoleUser, resource: ResourceAttributes, action: ActionUpdate, expected: true},
+		{name: "User cannot delete catalog data", role: models.RoleUser, resource: ResourceProducts, action: ActionDelete, expected: false},
+		{name: "User cannot delete images", role: models.RoleUser, resource: ResourceImages, action: ActionDelete, expected: false},
+		{name: "User cannot list users", role: models.RoleUser, resource: ResourceUsers, action: ActionRead, expected: false},
+		{name: "Unknown role is denied", role: models.UserRole("GUEST"), resource: ResourceCategories, action: ActionRead, expected: false},
+		{name: "Unknown resource is denied", role: models.RoleAdmin, resource: Resource("orders"), action: ActionRead, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Can(tc.role, tc.resource, tc.action))
+		})
+	}
+}
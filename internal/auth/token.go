@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidToken is returned when a token is malformed, expired or signed with another key
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// tokenTypeAccess marks JWTs that may be used to call the API
+const tokenTypeAccess = "access"
+
+// AccessClaims are the claims carried by an access token
+type AccessClaims struct {
+	jwt.RegisteredClaims
+	Role string `json:"role"`
+	Type string `json:"typ"`
+}
+
+// UserID returns the authenticated user ID stored in the subject claim
+func (c *AccessClaims) UserID() (uint, error) {
+	id, err := strconv.ParseUint(c.Subject, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+	return uint(id), nil
+}
+
+// TokenManager issues and verifies access tokens and generates refresh tokens
+type TokenManager struct {
+	secret             []byte
+	accessTokenExpiry  time.Duration
+	refreshTokenExpiry time.Duration
+	now                func() time.Time
+}
+
+// NewTokenManager creates a TokenManager from the JWT configuration
+func NewTokenManager(config *config.JWTConfig) *TokenManager {
+	return &TokenManager{
+		secret:             []byte(config.Secret),
+		accessTokenExpiry:  config.AccessTokenExpiry,
+		refreshTokenExpiry: config.RefreshTokenExpiry,
+		now:                time.Now,
+	}
+}
+
+// AccessTokenExpiry returns how long issued access tokens stay valid
+func (m *TokenManager) AccessTokenExpiry() time.Duration {
+	return m.accessTokenExpiry
+}
+
+// RefreshTokenExpiry returns how long issued refresh tokens stay valid
+func (m *TokenManager) RefreshTokenExpiry() time.Duration {
+	return m.refreshTokenExpiry
+}
+
+// IssueAccessToken signs a new access token for the user
+func (m *TokenManager) IssueAccessToken(userID uint, role string) (string, *AccessClaims, error) {
+	jti, err := randomToken(16)
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := m.now()
+	claims := &AccessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Subject:   strconv.FormatUint(uint64(userID), 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(m.accessTokenExpiry)),
+		},
+		Role: role,
+		Type: tokenTypeAccess,
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(m.secret)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to sign access token: %w", err)
+	}
+
+	return signed, claims, nil
+}
+
+// ParseAccessToken verifies the signature and expiry of an access token and returns its claims
+func (m *TokenManager) ParseAccessToken(tokenString string) (*AccessClaims, error) {
+	claims := &AccessClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims,
+		func(token *jwt.Token) (interface{}, error) {
+			return m.secret, nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithTimeFunc(m.now),
+	)
+	if err != nil || claims.Type != tokenTypeAccess || claims.ID == "" {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// NewRefreshToken returns a random opaque refresh token and the hash to persist
+func (m *TokenManager) NewRefreshToken() (token string, hash string, err error) {
+	token, err = randomToken(32)
+	if err != nil {
+		return "", "", err
+	}
+	return token, HashToken(token), nil
+}
+
+// NewFamilyID returns a random identifier grouping refresh tokens rotated from one login
+func NewFamilyID() (string, error) {
+	return randomToken(16)
+}
+
+// HashToken returns the hex encoded SHA-256 hash of an opaque token
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomToken returns n random bytes encoded as URL-safe base64
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
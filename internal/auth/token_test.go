@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestTokenManager(secret string) *TokenManager {
+	return NewTokenManager(&config.JWTConfig{
+		Secret:             secret,
+		AccessTokenExpiry:  15 * time.Minute,
+		RefreshTokenExpiry: 24 * time.Hour,
+	})
+}
+
+func TestIssueAndParseAccessToken(t *testing.T) {
+	// Setup
+	manager := newTestTokenManager("test-secret")
+
+	// Execute
+	token, issued, err := manager.IssueAccessToken(42, "ADMIN")
+	assert.NoError(t, err)
+	claims, err := manager.ParseAccessToken(token)
+
+	// Assert
+	assert.NoError(t, err)
+	userID, err := claims.UserID()
+	assert.NoError(t, err)
+	assert.Equal(t, uint(42), userID)
+	assert.Equal(t, "ADMIN", claims.Role)
+	assert.Equal(t, issued.ID, claims.ID)
+	assert.NotEmpty(t, claims.ID)
+}
+
+func TestParseAccessTokenRejectsInvalidTokens(t *testing.T) {
+	manager := newTestTokenManager("test-secret")
+	validToken, _, err := manager.IssueAccessToken(1, "USER")
+	assert.NoError(t, err)
+
+	t.Run("Signed with another secret", func(t *testing.T) {
+		_, err := newTestTokenManager("other-secret").ParseAccessToken(validToken)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		expired := newTestTokenManager("test-secret")
+		expired.now = func() time.Time { return time.Now().Add(-time.Hour) }
+		token, _, err := expired.IssueAccessToken(1, "USER")
+		assert.NoError(t, err)
+
+		_, err = manager.ParseAccessToken(token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Malformed token", func(t *testing.T) {
+		_, err := manager.ParseAccessToken("not-a-jwt")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Refresh token used as access token", func(t *testing.T) {
+		refreshToken, _, err := manager.NewRefreshToken()
+		assert.NoError(t, err)
+
+		_, err = manager.ParseAccessToken(refreshToken)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestNewRefreshToken(t *testing.T) {
+	// Setup
+	manager := newTestTokenManager("test-secret")
+
+	// Execute
+	first, firstHash, err := manager.NewRefreshToken()
+	assert.NoError(t, err)
+	second, _, err := manager.NewRefreshToken()
+	assert.NoError(t, err)
+
+	// Assert
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, HashToken(first), firstHash)
+	assert.Len(t, firstHash, 64)
+	assert.NotEqual(t, first, firstHash)
+}
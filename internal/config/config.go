@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"github.com/joho/godotenv"
 	"os"
 	"strconv"
@@ -12,12 +13,16 @@ type Config struct {
 	App      AppConfig
 	Database DatabaseConfig
 	JWT      JWTConfig
+	Slug     SlugConfig
+	Date     DateConfig
 }
 
 type AppConfig struct {
 	Name  string
 	Port  int
 	Debug bool
+	// CursorSecret signs pagination cursors; defaults to the JWT secret
+	CursorSecret string
 }
 
 type DatabaseConfig struct {
@@ -29,8 +34,21 @@ type DatabaseConfig struct {
 }
 
 type JWTConfig struct {
-	Secret            string
-	AccessTokenExpiry time.Duration
+	Secret             string
+	AccessTokenExpiry  time.Duration
+	RefreshTokenExpiry time.Duration
+}
+
+type SlugConfig struct {
+	// MaxLength cuts longer slugs at a word boundary; at most 100
+	MaxLength int
+	// TransliterationTables are extra table files, used before the built-in transliterations
+	TransliterationTables []string
+}
+
+type DateConfig struct {
+	// InputFormats adds or replaces date locales, e.g. "en-US=1/2/2006|Jan 2, 2006;en-GB=2/1/2006"
+	InputFormats string
 }
 
 func getEnvBool(key string) bool {
@@ -45,6 +63,23 @@ func getEnvInt(key string) int {
 	return value
 }
 
+func getEnvIntOrDefault(key string, defaultValue int) int {
+	if os.Getenv(key) == "" {
+		return defaultValue
+	}
+	return getEnvInt(key)
+}
+
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 func LoadConfig() (*Config, error) {
 	err := godotenv.Load()
 	if err != nil {
@@ -53,9 +88,10 @@ func LoadConfig() (*Config, error) {
 
 	config := &Config{
 		App: AppConfig{
-			Name:  os.Getenv("APP_NAME"),
-			Port:  getEnvInt("APP_PORT"),
-			Debug: getEnvBool("APP_DEBUG"),
+			Name:         os.Getenv("APP_NAME"),
+			Port:         getEnvInt("APP_PORT"),
+			Debug:        getEnvBool("APP_DEBUG"),
+			CursorSecret: os.Getenv("APP_CURSOR_SECRET"),
 		},
 		Database: DatabaseConfig{
 			Host:     os.Getenv("DB_HOST"),
@@ -65,9 +101,32 @@ func LoadConfig() (*Config, error) {
 			Name:     os.Getenv("DB_NAME"),
 		},
 		JWT: JWTConfig{
-			Secret:            os.Getenv("JWT_SECRET"),
-			AccessTokenExpiry: time.Duration(getEnvInt("JWT_ACCESS_TOKEN_EXPIRY")) * time.Second,
+			Secret: os.Getenv("JWT_SECRET"),
+			// Access tokens default to 15 minutes
+			AccessTokenExpiry: time.Duration(getEnvIntOrDefault("JWT_ACCESS_TOKEN_EXPIRY", 900)) * time.Second,
+			// Refresh tokens default to 7 days
+			RefreshTokenExpiry: time.Duration(getEnvIntOrDefault("JWT_REFRESH_TOKEN_EXPIRY", 604800)) * time.Second,
+		},
+		Slug: SlugConfig{
+			MaxLength:             getEnvIntOrDefault("SLUG_MAX_LENGTH", 100),
+			TransliterationTables: getEnvList("SLUG_TRANSLITERATION_TABLES"),
 		},
+		Date: DateConfig{
+			InputFormats: os.Getenv("DATE_INPUT_FORMATS"),
+		},
+	}
+
+	if config.JWT.Secret == "" {
+		return nil, errors.New("JWT_SECRET must be set")
+	}
+	if config.JWT.AccessTokenExpiry <= 0 || config.JWT.RefreshTokenExpiry <= 0 {
+		return nil, errors.New("JWT_ACCESS_TOKEN_EXPIRY and JWT_REFRESH_TOKEN_EXPIRY must be positive")
+	}
+	if config.Slug.MaxLength < 1 || config.Slug.MaxLength > 100 {
+		return nil, errors.New("SLUG_MAX_LENGTH must be between 1 and 100")
+	}
+	if config.App.CursorSecret == "" {
+		config.App.CursorSecret = config.JWT.Secret
 	}
 
 	return config, nil
@@ -0,0 +1,31 @@
+package database
+
+import (
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"gorm.io/gorm"
+)
+
+// RemoveDuplicateAttributeValues keeps only the most recently written value of
+// every SKU and attribute pair written before values were unique per position,
+// so that AutoMigrate can create the unique models.SkuAttributeValueIndex. The
+// other values are soft-deleted rather than dropped, so they can still be
+// recovered. It does nothing once that index exists. Returns the IDs of the
+// removed values.
+func RemoveDuplicateAttributeValues(db *gorm.DB) ([]uint, error) {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&models.SkuAttributeValue{}) ||
+		migrator.HasIndex(&models.SkuAttributeValue{}, models.SkuAttributeValueIndex) {
+		return nil, nil
+	}
+
+	var removed []uint
+	err := db.Raw(`
+		UPDATE sku_attribute_values AS v SET deleted_at = NOW()
+		WHERE v.deleted_at IS NULL AND EXISTS (
+			SELECT 1 FROM sku_attribute_values AS kept
+			WHERE kept.sku_id = v.sku_id AND kept.attribute_id = v.attribute_id
+				AND kept.deleted_at IS NULL
+				AND (kept.updated_at > v.updated_at OR (kept.updated_at = v.updated_at AND kept.id > v.id)))
+		RETURNING v.id`).Scan(&removed).Error
+	return removed, err
+}
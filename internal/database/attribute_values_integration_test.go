@@ -0,0 +1,73 @@
+//go:build integration
+// +build integration
+
+package database_test
+
+import (
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/database"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/internal/testutil"
+)
+
+func TestRemoveDuplicateAttributeValues_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testUser := models.User{Username: "testuser", Password: "password123", Name: "Test User", Role: models.RoleUser}
+	if err := db.Create(&testUser).Error; err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	audit := models.Base{CreatedBy: testUser.ID, UpdatedBy: testUser.ID}
+
+	category := models.Category{Name: "Laptops", Base: audit}
+	db.Create(&category)
+	product := models.Product{Name: "ROG Strix", CategoryID: category.ID, Base: audit}
+	db.Create(&product)
+	sku := models.Sku{Name: "ROG Strix 16GB", SkuNumber: "ROG-16", Price: 100, ProductID: product.ID, Base: audit}
+	db.Create(&sku)
+	ram := models.Attribute{Name: "RAM", Code: "ram", DataType: models.DataTypeNumber, Base: audit}
+	db.Create(&ram)
+
+	// Simulate duplicates written before the unique index existed
+	if err := db.Migrator().DropIndex(&models.SkuAttributeValue{}, models.SkuAttributeValueIndex); err != nil {
+		t.Fatalf("Failed to drop index: %v", err)
+	}
+	for _, value := range []string{"16", "32", "64"} {
+		db.Exec("INSERT INTO sku_attribute_values (created_at, updated_at, sku_id, attribute_id, value) VALUES (NOW(), NOW(), ?, ?, ?)",
+			sku.ID, ram.ID, value)
+	}
+
+	removed, err := database.RemoveDuplicateAttributeValues(db)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if len(removed) != 2 {
+		t.Errorf("Expected 2 removed values, got %v", removed)
+	}
+
+	var values []models.SkuAttributeValue
+	db.Where("sku_id = ?", sku.ID).Find(&values)
+	if len(values) != 1 || values[0].Value != "64" {
+		t.Errorf("Expected only the most recent value '64' to be kept, got %+v", values)
+	}
+
+	var deleted []models.SkuAttributeValue
+	db.Unscoped().Where("sku_id = ? AND deleted_at IS NOT NULL", sku.ID).Order("id").Find(&deleted)
+	if len(deleted) != 2 || deleted[0].Value != "16" || deleted[1].Value != "32" {
+		t.Errorf("Expected '16' and '32' to be soft-deleted, got %+v", deleted)
+	}
+
+	if err := database.AutoMigrate(db); err != nil {
+		t.Fatalf("Expected the unique index to be created, got: %v", err)
+	}
+	if !db.Migrator().HasIndex(&models.SkuAttributeValue{}, models.SkuAttributeValueIndex) {
+		t.Error("Expected the unique index to exist")
+	}
+
+	removed, err = database.RemoveDuplicateAttributeValues(db)
+	if err != nil || len(removed) != 0 {
+		t.Errorf("Expected nothing to do once the index exists, got %v (%v)", removed, err)
+	}
+}
@@ -0,0 +1,153 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/auth"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	createdByField = "CreatedBy"
+	updatedByField = "UpdatedBy"
+)
+
+// AuditPlugin is a GORM plugin that stamps the CreatedBy/UpdatedBy fields of
+// every model that has them. The acting user is read from the context passed
+// to db.WithContext (see auth.WithUser); without one, the seeded system user
+// is used so background jobs still write valid foreign keys.
+//
+// On create, values that are already set are kept. On update, UpdatedBy is
+// always replaced. Statements that skip hooks, such as UpdateColumn, are left alone.
+type AuditPlugin struct {
+	mu           sync.Mutex
+	systemUserID uint
+}
+
+// NewAuditPlugin creates a new AuditPlugin
+func NewAuditPlugin() *AuditPlugin {
+	return &AuditPlugin{}
+}
+
+// Name implements gorm.Plugin
+func (p *AuditPlugin) Name() string {
+	return "audit"
+}
+
+// Initialize implements gorm.Plugin by registering the create and update callbacks
+func (p *AuditPlugin) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Create().Before("gorm:create").Register("audit:stamp_create", p.stampCreate); err != nil {
+		return err
+	}
+	return db.Callback().Update().Before("gorm:update").Register("audit:stamp_update", p.stampUpdate)
+}
+
+// stampCreate fills CreatedBy and UpdatedBy on every record that leaves them unset
+func (p *AuditPlugin) stampCreate(db *gorm.DB) {
+	if !p.applies(db) {
+		return
+	}
+
+	var fields []*schema.Field
+	for _, name := range []string{createdByField, updatedByField} {
+		if field := db.Statement.Schema.LookUpField(name); field != nil {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return
+	}
+
+	var records []reflect.Value
+	switch rv := reflect.Indirect(db.Statement.ReflectValue); rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			records = append(records, reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		records = append(records, rv)
+	}
+
+	// Only resolve the actor when a record actually needs stamping
+	var actorID uint
+	for _, record := range records {
+		for _, field := range fields {
+			if _, isZero := field.ValueOf(db.Statement.Context, record); !isZero {
+				continue
+			}
+			if actorID == 0 {
+				id, err := p.actorID(db)
+				if err != nil {
+					db.AddError(err)
+					return
+				}
+				actorID = id
+			}
+			db.AddError(field.Set(db.Statement.Context, record, actorID))
+		}
+	}
+}
+
+// stampUpdate sets UpdatedBy to the acting user
+func (p *AuditPlugin) stampUpdate(db *gorm.DB) {
+	if !p.applies(db) {
+		return
+	}
+
+	field := db.Statement.Schema.LookUpField(updatedByField)
+	if field == nil {
+		return
+	}
+
+	actorID, err := p.actorID(db)
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+
+	db.Statement.SetColumn(field.DBName, actorID, true)
+}
+
+// applies reports whether the statement targets a model and runs hooks
+func (p *AuditPlugin) applies(db *gorm.DB) bool {
+	return db.Error == nil && db.Statement.Schema != nil && !db.Statement.SkipHooks
+}
+
+// actorID returns the ID of the user in the statement context, falling back to the system user
+func (p *AuditPlugin) actorID(db *gorm.DB) (uint, error) {
+	if user, ok := auth.UserFromContext(db.Statement.Context); ok && user.ID != 0 {
+		return user.ID, nil
+	}
+	return p.systemUser(db)
+}
+
+// systemUser looks up and caches the ID of the seeded system user
+func (p *AuditPlugin) systemUser(db *gorm.DB) (uint, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.systemUserID != 0 {
+		return p.systemUserID, nil
+	}
+
+	// A fresh statement on the same connection pool keeps the lookup inside any open transaction
+	var user models.User
+	err := db.Session(&gorm.Session{NewDB: true}).
+		Select("id").
+		Where("username = ?", models.SystemUsername).
+		First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, fmt.Errorf("audit: no user in context and system user %q does not exist", models.SystemUsername)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("audit: failed to look up system user: %w", err)
+	}
+
+	p.systemUserID = user.ID
+	return p.systemUserID, nil
+}
@@ -0,0 +1,90 @@
+//go:build integration
+// +build integration
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/auth"
+	"github.com/Wilson1510/klampis-pim-go/internal/database"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/internal/testutil"
+)
+
+func TestAuditPlugin_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	if err := db.Use(database.NewAuditPlugin()); err != nil {
+		t.Fatalf("Failed to register audit plugin: %v", err)
+	}
+
+	systemUser := models.User{Username: models.SystemUsername, Password: "password123", Name: "System", Role: models.RoleSystem}
+	if err := db.Create(&systemUser).Error; err != nil {
+		t.Fatalf("Failed to create system user: %v", err)
+	}
+	editor := models.User{Username: "editor", Password: "password123", Name: "Editor", Role: models.RoleUser}
+	if err := db.Create(&editor).Error; err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+
+	t.Run("Falls back to the system user without a context user", func(t *testing.T) {
+		category := models.Category{Name: "Background Job"}
+		if err := db.Create(&category).Error; err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if category.CreatedBy != systemUser.ID || category.UpdatedBy != systemUser.ID {
+			t.Errorf("Expected audit fields %d, got created_by=%d updated_by=%d",
+				systemUser.ID, category.CreatedBy, category.UpdatedBy)
+		}
+	})
+
+	t.Run("Stamps the context user on create and update", func(t *testing.T) {
+		ctx := auth.WithUser(context.Background(), &editor, nil)
+
+		category := models.Category{Name: "Electronics"}
+		if err := db.Create(&category).Error; err != nil {
+			t.Fatalf("Failed to create category: %v", err)
+		}
+
+		category.Description = "Edited by a user"
+		if err := db.WithContext(ctx).Save(&category).Error; err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		var stored models.Category
+		db.First(&stored, category.ID)
+		if stored.CreatedBy != systemUser.ID {
+			t.Errorf("Expected created_by %d to be kept, got %d", systemUser.ID, stored.CreatedBy)
+		}
+		if stored.UpdatedBy != editor.ID {
+			t.Errorf("Expected updated_by %d, got %d", editor.ID, stored.UpdatedBy)
+		}
+	})
+
+	t.Run("Stamps SkuAttributeValue", func(t *testing.T) {
+		ctx := auth.WithUser(context.Background(), &editor, nil)
+
+		category := models.Category{Name: "Laptops"}
+		db.Create(&category)
+		product := models.Product{Name: "ROG Strix", CategoryID: category.ID}
+		db.Create(&product)
+		sku := models.Sku{Name: "ROG Strix 16GB", SkuNumber: "ROG-16GB-001", ProductID: product.ID, Price: 1000}
+		if err := db.Create(&sku).Error; err != nil {
+			t.Fatalf("Failed to create sku: %v", err)
+		}
+		attribute := models.Attribute{Name: "RAM", Code: "ram", DataType: models.DataTypeNumber}
+		db.Create(&attribute)
+
+		value := models.SkuAttributeValue{SkuID: sku.ID, AttributeID: attribute.ID, Value: "16"}
+		if err := db.WithContext(ctx).Create(&value).Error; err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if value.CreatedBy != editor.ID || value.UpdatedBy != editor.ID {
+			t.Errorf("Expected audit fields %d, got created_by=%d updated_by=%d",
+				editor.ID, value.CreatedBy, value.UpdatedBy)
+		}
+	})
+}
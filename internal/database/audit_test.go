@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/auth"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// auditedRecord is a minimal model with audit fields and no hooks of its own
+type auditedRecord struct {
+	ID        uint
+	Name      string
+	CreatedBy uint
+	UpdatedBy uint
+}
+
+// newDryRunDB opens a GORM handle that builds statements without touching a database
+func newDryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(NewAuditPlugin()))
+	return db
+}
+
+func TestAuditPlugin_Create(t *testing.T) {
+	db := newDryRunDB(t)
+	ctx := auth.WithUser(context.Background(), &models.User{Model: gorm.Model{ID: 7}}, nil)
+
+	testCases := []struct {
+		name              string
+		record            auditedRecord
+		expectedCreatedBy uint
+		expectedUpdatedBy uint
+	}{
+		{name: "Stamps unset fields", record: auditedRecord{}, expectedCreatedBy: 7, expectedUpdatedBy: 7},
+		{name: "Keeps explicit fields", record: auditedRecord{CreatedBy: 3, UpdatedBy: 4}, expectedCreatedBy: 3, expectedUpdatedBy: 4},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			record := tc.record
+			require.NoError(t, db.WithContext(ctx).Create(&record).Error)
+
+			assert.Equal(t, tc.expectedCreatedBy, record.CreatedBy)
+			assert.Equal(t, tc.expectedUpdatedBy, record.UpdatedBy)
+		})
+	}
+}
+
+func TestAuditPlugin_CreateBatch(t *testing.T) {
+	db := newDryRunDB(t)
+	ctx := auth.WithUser(context.Background(), &models.User{Model: gorm.Model{ID: 7}}, nil)
+
+	records := []auditedRecord{{Name: "a"}, {Name: "b", CreatedBy: 3}}
+	require.NoError(t, db.WithContext(ctx).Create(&records).Error)
+
+	assert.Equal(t, uint(7), records[0].CreatedBy)
+	assert.Equal(t, uint(3), records[1].CreatedBy)
+	assert.Equal(t, uint(7), records[1].UpdatedBy)
+}
+
+func TestAuditPlugin_Update(t *testing.T) {
+	db := newDryRunDB(t)
+	ctx := auth.WithUser(context.Background(), &models.User{Model: gorm.Model{ID: 7}}, nil)
+
+	t.Run("Save replaces UpdatedBy", func(t *testing.T) {
+		record := auditedRecord{ID: 1, CreatedBy: 3, UpdatedBy: 3}
+		stmt := db.WithContext(ctx).Save(&record).Statement
+
+		assert.Equal(t, uint(3), record.CreatedBy)
+		assert.Equal(t, uint(7), record.UpdatedBy)
+		assert.Contains(t, stmt.SQL.String(), `"updated_by"=`)
+	})
+
+	t.Run("Map update gains updated_by", func(t *testing.T) {
+		values := map[string]interface{}{"name": "renamed"}
+		stmt := db.WithContext(ctx).Model(&auditedRecord{ID: 1}).Updates(values).Statement
+
+		assert.Equal(t, uint(7), values["updated_by"])
+		assert.Contains(t, stmt.SQL.String(), `"updated_by"=`)
+	})
+
+	t.Run("UpdateColumn is left alone", func(t *testing.T) {
+		stmt := db.WithContext(ctx).Model(&auditedRecord{ID: 1}).UpdateColumn("name", "renamed").Statement
+
+		assert.NotContains(t, stmt.SQL.String(), "updated_by")
+	})
+}
@@ -0,0 +1,29 @@
+package database
+
+import (
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"gorm.io/gorm"
+)
+
+// BackfillBaseValues computes the base values of NUMBER values stored before
+// values were normalized to the base unit of their dimension, attribute by
+// attribute. Only plain numbers without a base value are read and written, in
+// batches, so a start with nothing left to backfill costs one query. Returns the
+// number of attributes whose values were updated.
+func BackfillBaseValues(db *gorm.DB) (int, error) {
+	var attributes []models.Attribute
+	missing := db.Unscoped().Model(&models.SkuAttributeValue{}).Select("attribute_id").
+		Where("base_value IS NULL AND value ~ ?", models.NumberValuePattern)
+	err := db.Where("data_type = ? AND id IN (?)", models.DataTypeNumber, missing).Find(&attributes).Error
+	if err != nil {
+		return 0, err
+	}
+
+	for i, attribute := range attributes {
+		if _, err := attribute.FillMissingBaseValues(db); err != nil {
+			return i, err
+		}
+	}
+
+	return len(attributes), nil
+}
@@ -0,0 +1,72 @@
+//go:build integration
+// +build integration
+
+package database_test
+
+import (
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/database"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/internal/testutil"
+)
+
+func TestBackfillBaseValues_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testUser := models.User{Username: "testuser", Password: "password123", Name: "Test User", Role: models.RoleUser}
+	if err := db.Create(&testUser).Error; err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	audit := models.Base{CreatedBy: testUser.ID, UpdatedBy: testUser.ID}
+
+	category := models.Category{Name: "Laptops", Base: audit}
+	db.Create(&category)
+	product := models.Product{Name: "ROG Strix", CategoryID: category.ID, Base: audit}
+	db.Create(&product)
+	sku := models.Sku{Name: "ROG Strix 16GB", SkuNumber: "ROG-16", Price: 100, ProductID: product.ID, Base: audit}
+	db.Create(&sku)
+
+	ram := models.Attribute{Name: "RAM", Code: "ram", DataType: models.DataTypeNumber, UOM: "GB", Base: audit}
+	db.Create(&ram)
+	model := models.Attribute{Name: "Model", Code: "model", DataType: models.DataTypeText, Base: audit}
+	db.Create(&model)
+	battery := models.Attribute{Name: "Battery", Code: "battery", DataType: models.DataTypeNumber, UOM: "h", Base: audit}
+	db.Create(&battery)
+
+	// Simulate values stored before base values existed
+	db.Exec("INSERT INTO sku_attribute_values (created_at, updated_at, sku_id, attribute_id, value) VALUES (NOW(), NOW(), ?, ?, ?)",
+		sku.ID, ram.ID, "16")
+	db.Exec("INSERT INTO sku_attribute_values (created_at, updated_at, sku_id, attribute_id, value) VALUES (NOW(), NOW(), ?, ?, ?)",
+		sku.ID, model.ID, "G15")
+	db.Exec("INSERT INTO sku_attribute_values (created_at, updated_at, sku_id, attribute_id, value) VALUES (NOW(), NOW(), ?, ?, ?)",
+		sku.ID, battery.ID, "n/a")
+
+	normalized, err := database.BackfillBaseValues(db)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if normalized != 1 {
+		t.Errorf("Expected 1 attribute, got %d", normalized)
+	}
+
+	var values []models.SkuAttributeValue
+	db.Where("sku_id = ?", sku.ID).Order("attribute_id").Find(&values)
+	if values[0].BaseValue == nil || *values[0].BaseValue != 16e9 {
+		t.Errorf("Expected base value 1.6e10 for RAM, got %v", values[0].BaseValue)
+	}
+	if values[1].BaseValue != nil {
+		t.Errorf("Expected no base value for text, got %v", *values[1].BaseValue)
+	}
+
+	if values[2].BaseValue != nil {
+		t.Errorf("Expected no base value for a value that is not a number, got %v", *values[2].BaseValue)
+	}
+
+	// Values without a base value by design are not read again
+	normalized, err = database.BackfillBaseValues(db)
+	if err != nil || normalized != 0 {
+		t.Errorf("Expected nothing left to backfill, got %d (%v)", normalized, err)
+	}
+}
@@ -0,0 +1,26 @@
+package database
+
+import "gorm.io/gorm"
+
+// BackfillCategoryPaths computes the materialized ID path and slug path of every
+// category from parent_id, including soft-deleted ones. Only rows whose paths
+// differ are written, so it is safe to run on every start. Categories caught in a
+// parent_id cycle are never reached from a root and keep their current paths.
+// Returns the number of updated categories.
+func BackfillCategoryPaths(db *gorm.DB) (int64, error) {
+	result := db.Exec(`
+		WITH RECURSIVE paths AS (
+			SELECT id, '/' || id || '/' AS path, slug::text AS full_path
+			FROM categories
+			WHERE parent_id IS NULL
+			UNION ALL
+			SELECT c.id, paths.path || c.id || '/', paths.full_path || '/' || c.slug
+			FROM categories c JOIN paths ON c.parent_id = paths.id
+			WHERE paths.path NOT LIKE '%/' || c.id || '/%'
+		)
+		UPDATE categories SET path = paths.path, full_path = paths.full_path
+		FROM paths
+		WHERE categories.id = paths.id
+			AND (categories.path IS DISTINCT FROM paths.path OR categories.full_path IS DISTINCT FROM paths.full_path)`)
+	return result.RowsAffected, result.Error
+}
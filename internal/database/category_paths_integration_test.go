@@ -0,0 +1,59 @@
+//go:build integration
+// +build integration
+
+package database_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/database"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/internal/testutil"
+)
+
+func TestBackfillCategoryPaths_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testUser := models.User{Username: "testuser", Password: "password123", Name: "Test User", Role: models.RoleUser}
+	if err := db.Create(&testUser).Error; err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	audit := models.Base{CreatedBy: testUser.ID, UpdatedBy: testUser.ID}
+
+	root := models.Category{Name: "Root", Base: audit}
+	db.Create(&root)
+	child := models.Category{Name: "Child", ParentID: &root.ID, Base: audit}
+	db.Create(&child)
+	grandchild := models.Category{Name: "Grandchild", ParentID: &child.ID, Base: audit}
+	db.Create(&grandchild)
+	db.Delete(&grandchild)
+
+	// Simulate rows created before paths were maintained
+	db.Exec("UPDATE categories SET path = '', full_path = ''")
+
+	updated, err := database.BackfillCategoryPaths(db)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if updated != 3 {
+		t.Errorf("Expected 3 updated categories, got %d", updated)
+	}
+
+	var stored models.Category
+	db.Unscoped().First(&stored, grandchild.ID)
+	expected := fmt.Sprintf("/%d/%d/%d/", root.ID, child.ID, grandchild.ID)
+	if stored.Path != expected {
+		t.Errorf("Expected soft-deleted grandchild path '%s', got '%s'", expected, stored.Path)
+	}
+	if stored.FullPath != "root/child/grandchild" {
+		t.Errorf("Expected full path 'root/child/grandchild', got '%s'", stored.FullPath)
+	}
+
+	// Running again is a no-op
+	updated, err = database.BackfillCategoryPaths(db)
+	if err != nil || updated != 0 {
+		t.Errorf("Expected second run to update nothing, got %d (err: %v)", updated, err)
+	}
+}
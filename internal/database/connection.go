@@ -32,6 +32,11 @@ func NewConnection(config *config.DatabaseConfig) (*gorm.DB, error) {
 		return nil, err
 	}
 
+	// Stamp CreatedBy/UpdatedBy from the request user on every write
+	if err := db.Use(NewAuditPlugin()); err != nil {
+		return nil, err
+	}
+
 	return db, nil
 }
 
@@ -44,5 +49,11 @@ func AutoMigrate(db *gorm.DB) error {
 		&models.Sku{},
 		&models.Attribute{},
 		&models.SkuAttributeValue{},
+		&models.Image{},
+		&models.RefreshToken{},
+		&models.RevokedToken{},
+		&models.SlugHistory{},
+		&models.SlugSequence{},
+		&models.AttributeOption{},
 	)
 }
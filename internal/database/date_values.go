@@ -0,0 +1,67 @@
+package database
+
+import (
+	"time"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// legacyDateLayouts are the layouts dates were accepted in before they were
+// stored in ISO form, in the order they were tried. 03/04/2024 was read as
+// 3 April, so it is rewritten that way.
+var legacyDateLayouts = []string{
+	utils.DateLayout,
+	"2006-01-02 15:04:05",
+	time.RFC3339,
+	"02/01/2006",
+	"01/02/2006",
+}
+
+// CanonicalizeDateValues rewrites the values of DATE attributes that are not
+// stored in ISO form yet, keeping the date they were read as when they were
+// written. Values that no longer parse are left for review. It is safe to run
+// on every start. Returns the number of rewritten values.
+func CanonicalizeDateValues(db *gorm.DB) (int, error) {
+	var values []models.SkuAttributeValue
+	rewritten := 0
+
+	err := db.Unscoped().
+		Select("sku_attribute_values.id", "sku_attribute_values.value").
+		Joins("JOIN attributes ON attributes.id = sku_attribute_values.attribute_id").
+		Where("attributes.data_type = ? AND sku_attribute_values.value !~ ?", models.DataTypeDate, `^[0-9]{4}-[0-9]{2}-[0-9]{2}$`).
+		FindInBatches(&values, 200, func(tx *gorm.DB, batch int) error {
+			for _, value := range values {
+				date, ok := parseLegacyDate(value.Value)
+				if !ok {
+					continue
+				}
+
+				// UpdateColumn skips hooks and leaves updated_at untouched
+				err := db.Unscoped().Model(&models.SkuAttributeValue{}).
+					Where("id = ?", value.ID).
+					UpdateColumn("value", date.Format(utils.DateLayout)).Error
+				if err != nil {
+					return err
+				}
+				rewritten++
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return rewritten, err
+	}
+
+	return rewritten, nil
+}
+
+// parseLegacyDate parses a date the way it was read before dates were stored in ISO form
+func parseLegacyDate(value string) (time.Time, bool) {
+	for _, layout := range legacyDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,76 @@
+//go:build integration
+// +build integration
+
+package database_test
+
+import (
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/database"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/internal/testutil"
+)
+
+func TestCanonicalizeDateValues_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testUser := models.User{Username: "testuser", Password: "password123", Name: "Test User", Role: models.RoleUser}
+	if err := db.Create(&testUser).Error; err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	audit := models.Base{CreatedBy: testUser.ID, UpdatedBy: testUser.ID}
+
+	category := models.Category{Name: "Laptops", Base: audit}
+	db.Create(&category)
+	product := models.Product{Name: "ROG Strix", CategoryID: category.ID, Base: audit}
+	db.Create(&product)
+
+	warranty := models.Attribute{Name: "Warranty Expiry", Code: "warranty_expiry", DataType: models.DataTypeDate, Base: audit}
+	db.Create(&warranty)
+	model := models.Attribute{Name: "Model", Code: "model", DataType: models.DataTypeText, Base: audit}
+	db.Create(&model)
+
+	// Simulate values stored before dates were stored in ISO form
+	stored := []struct {
+		attributeID uint
+		value       string
+		expected    string
+	}{
+		{warranty.ID, "2024-12-31", "2024-12-31"},
+		{warranty.ID, "2024-12-31 10:00:00", "2024-12-31"},
+		{warranty.ID, "03/04/2024", "2024-04-03"},
+		{warranty.ID, "12/31/2023", "2023-12-31"},
+		{warranty.ID, "someday", "someday"},
+		{model.ID, "03/04/2024", "03/04/2024"},
+	}
+	skuIDs := make([]uint, len(stored))
+	for i, value := range stored {
+		sku := models.Sku{Name: "ROG Strix " + value.value, SkuNumber: "ROG-" + string(rune('A'+i)), Price: 100, ProductID: product.ID, Base: audit}
+		db.Create(&sku)
+		skuIDs[i] = sku.ID
+		db.Exec("INSERT INTO sku_attribute_values (created_at, updated_at, sku_id, attribute_id, value) VALUES (NOW(), NOW(), ?, ?, ?)",
+			sku.ID, value.attributeID, value.value)
+	}
+
+	rewritten, err := database.CanonicalizeDateValues(db)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if rewritten != 3 {
+		t.Errorf("Expected 3 rewritten values, got %d", rewritten)
+	}
+
+	for i, value := range stored {
+		var got models.SkuAttributeValue
+		db.Where("sku_id = ? AND attribute_id = ?", skuIDs[i], value.attributeID).First(&got)
+		if got.Value != value.expected {
+			t.Errorf("Expected %q to be stored as %q, got %q", value.value, value.expected, got.Value)
+		}
+	}
+
+	rewritten, err = database.CanonicalizeDateValues(db)
+	if err != nil || rewritten != 0 {
+		t.Errorf("Expected nothing left to rewrite, got %d (%v)", rewritten, err)
+	}
+}
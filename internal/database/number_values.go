@@ -0,0 +1,33 @@
+package database
+
+import "gorm.io/gorm"
+
+// FlagRoundedNumberValues checks the SKU values that have not been checked yet
+// and flags the numbers that may have been rounded when numbers were still
+// formatted with two decimal places. The rounding itself left no trace, so this
+// is a heuristic: every number with exactly two decimals is flagged, which
+// over-flags values entered that way, like an exact 16.00 or a price of 9.99.
+// Attributes whose precision rule is two decimal places are exempt, but that
+// rule did not exist when the values were written, so it only tells what the
+// attribute holds today; a value rounded before the rule was added is missed.
+// Values written since are never flagged, and each value is only checked once,
+// so it is safe to run on every start. Returns the number of checked and of
+// flagged values.
+func FlagRoundedNumberValues(db *gorm.DB) (checked, flagged int64, err error) {
+	var counts struct {
+		Checked int64
+		Flagged int64
+	}
+	err = db.Raw(`
+		WITH checked AS (
+			UPDATE sku_attribute_values AS v
+			SET possibly_rounded = (a.data_type = 'NUMBER' AND v.value ~ '^-?[0-9]+\.[0-9]{2}$'
+				AND a."precision" IS DISTINCT FROM 2)
+			FROM attributes AS a
+			WHERE a.id = v.attribute_id AND v.possibly_rounded IS NULL
+			RETURNING v.possibly_rounded
+		)
+		SELECT COUNT(*) AS checked, COUNT(*) FILTER (WHERE possibly_rounded) AS flagged
+		FROM checked`).Scan(&counts).Error
+	return counts.Checked, counts.Flagged, err
+}
@@ -0,0 +1,92 @@
+//go:build integration
+// +build integration
+
+package database_test
+
+import (
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/database"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/internal/testutil"
+)
+
+func TestFlagRoundedNumberValues_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testUser := models.User{Username: "testuser", Password: "password123", Name: "Test User", Role: models.RoleUser}
+	if err := db.Create(&testUser).Error; err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	audit := models.Base{CreatedBy: testUser.ID, UpdatedBy: testUser.ID}
+
+	category := models.Category{Name: "Laptops", Base: audit}
+	db.Create(&category)
+	product := models.Product{Name: "ROG Strix", CategoryID: category.ID, Base: audit}
+	db.Create(&product)
+	sku := models.Sku{Name: "ROG Strix 16GB", SkuNumber: "ROG-16", Price: 100, ProductID: product.ID, Base: audit}
+	db.Create(&sku)
+
+	cpu := models.Attribute{Name: "CPU Speed", Code: "cpu_speed", DataType: models.DataTypeNumber, Base: audit}
+	db.Create(&cpu)
+	ram := models.Attribute{Name: "RAM", Code: "ram", DataType: models.DataTypeNumber, Base: audit}
+	db.Create(&ram)
+	model := models.Attribute{Name: "Model", Code: "model", DataType: models.DataTypeText, Base: audit}
+	db.Create(&model)
+	twoDecimals := 2
+	weight := models.Attribute{Name: "Weight", Code: "weight", DataType: models.DataTypeNumber,
+		Rules: models.AttributeRules{Precision: &twoDecimals}, Base: audit}
+	db.Create(&weight)
+
+	// Simulate values stored before the check existed
+	for _, value := range []struct {
+		attributeID uint
+		value       string
+	}{{cpu.ID, "2.63"}, {ram.ID, "16"}, {model.ID, "12.50"}, {weight.ID, "2.35"}} {
+		db.Exec("INSERT INTO sku_attribute_values (created_at, updated_at, sku_id, attribute_id, value) VALUES (NOW(), NOW(), ?, ?, ?)",
+			sku.ID, value.attributeID, value.value)
+	}
+
+	checked, flagged, err := database.FlagRoundedNumberValues(db)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if checked != 4 || flagged != 1 {
+		t.Errorf("Expected 4 checked and 1 flagged value, got %d and %d", checked, flagged)
+	}
+
+	var values []models.SkuAttributeValue
+	db.Where("sku_id = ?", sku.ID).Order("attribute_id").Find(&values)
+	for _, value := range values {
+		expected := value.AttributeID == cpu.ID
+		if value.PossiblyRounded == nil || *value.PossiblyRounded != expected {
+			t.Errorf("Expected possibly_rounded=%v for %q, got %v", expected, value.Value, value.PossiblyRounded)
+		}
+	}
+
+	// Saving the value unchanged keeps the flag
+	db.Where("sku_id = ? AND attribute_id = ?", sku.ID, cpu.ID).First(&values[0])
+	values[0].Sequence = 3
+	if err := db.Save(&values[0]).Error; err != nil {
+		t.Fatalf("Failed to save value: %v", err)
+	}
+	db.First(&values[0], values[0].ID)
+	if values[0].PossiblyRounded == nil || !*values[0].PossiblyRounded {
+		t.Error("Expected the flag to be kept when the value is unchanged")
+	}
+
+	// Writing a new value clears the flag
+	values[0].Value = "2.625"
+	if err := db.Save(&values[0]).Error; err != nil {
+		t.Fatalf("Failed to save value: %v", err)
+	}
+	if values[0].PossiblyRounded == nil || *values[0].PossiblyRounded {
+		t.Error("Expected the flag to be cleared")
+	}
+
+	checked, _, err = database.FlagRoundedNumberValues(db)
+	if err != nil || checked != 0 {
+		t.Errorf("Expected nothing left to check, got %d (%v)", checked, err)
+	}
+}
@@ -0,0 +1,48 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/pkg/utils"
+	"gorm.io/gorm"
+)
+
+// HashPlaintextPasswords finds users whose password is still stored in plaintext
+// and replaces it with a bcrypt hash. It is safe to run on every start: rows that
+// are already hashed are skipped. Returns the number of rehashed users.
+func HashPlaintextPasswords(db *gorm.DB) (int, error) {
+	var users []models.User
+	rehashed := 0
+
+	err := db.Unscoped().
+		Select("id", "password").
+		Where("password <> ''").
+		FindInBatches(&users, 200, func(tx *gorm.DB, batch int) error {
+			for _, user := range users {
+				if utils.IsPasswordHash(user.Password) {
+					continue
+				}
+
+				hash, err := utils.HashPassword(user.Password)
+				if err != nil {
+					return fmt.Errorf("failed to hash password of user %d: %w", user.ID, err)
+				}
+
+				// UpdateColumn skips hooks and leaves updated_at untouched
+				err = db.Unscoped().Model(&models.User{}).
+					Where("id = ?", user.ID).
+					UpdateColumn("password", hash).Error
+				if err != nil {
+					return err
+				}
+				rehashed++
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return rehashed, err
+	}
+
+	return rehashed, nil
+}
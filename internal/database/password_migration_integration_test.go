@@ -0,0 +1,61 @@
+//go:build integration
+// +build integration
+
+package database_test
+
+import (
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/database"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/internal/testutil"
+	"github.com/Wilson1510/klampis-pim-go/pkg/utils"
+)
+
+func TestHashPlaintextPasswords_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	// Create a user through the hook (hashed) and simulate a legacy plaintext row
+	hashedUser := models.User{Username: "hashed", Password: "password123", Name: "Hashed", Role: models.RoleUser}
+	if err := db.Create(&hashedUser).Error; err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	legacyUser := models.User{Username: "legacy", Password: "placeholder", Name: "Legacy", Role: models.RoleUser}
+	if err := db.Create(&legacyUser).Error; err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	db.Model(&legacyUser).UpdateColumn("password", "system123")
+
+	var before models.User
+	db.First(&before, hashedUser.ID)
+
+	rehashed, err := database.HashPlaintextPasswords(db)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if rehashed != 1 {
+		t.Errorf("Expected 1 rehashed user, got %d", rehashed)
+	}
+
+	var legacy models.User
+	db.First(&legacy, legacyUser.ID)
+	if !utils.IsPasswordHash(legacy.Password) {
+		t.Error("Expected legacy password to be hashed")
+	}
+	if !legacy.CheckPassword("system123") {
+		t.Error("Expected legacy password to still verify after rehashing")
+	}
+
+	var after models.User
+	db.First(&after, hashedUser.ID)
+	if after.Password != before.Password {
+		t.Error("Expected already hashed password to be left untouched")
+	}
+
+	// Running again is a no-op
+	rehashed, err = database.HashPlaintextPasswords(db)
+	if err != nil || rehashed != 0 {
+		t.Errorf("Expected second run to rehash nothing, got %d (err: %v)", rehashed, err)
+	}
+}
@@ -0,0 +1,126 @@
+package mapper
+
+import (
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/response"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+)
+
+// ToAttributeResponse converts an Attribute model to AttributeResponse DTO
+func ToAttributeResponse(attribute *models.Attribute) response.AttributeResponse {
+	resp := response.AttributeResponse{
+		ID:          attribute.ID,
+		Name:        attribute.Name,
+		Code:        attribute.Code,
+		DataType:    string(attribute.DataType),
+		UOM:         attribute.UOM,
+		MultiValued: attribute.MultiValued,
+		Rules:       ToAttributeRulesResponse(&attribute.Rules),
+		IsActive:    attribute.IsActive,
+		CreatedAt:   attribute.CreatedAt,
+		UpdatedAt:   attribute.UpdatedAt,
+		Options:     ToAttributeOptionResponseList(attribute.Options),
+	}
+	if unit, ok := attribute.Unit(); ok {
+		resp.Dimension = string(unit.Dimension)
+	}
+
+	return resp
+}
+
+// ToUnitResponse converts a Unit of the unit catalog to UnitResponse DTO
+func ToUnitResponse(unit *models.Unit) response.UnitResponse {
+	return response.UnitResponse{
+		Code:      unit.Code,
+		Name:      unit.Name,
+		Dimension: string(unit.Dimension),
+		BaseUnit:  unit.BaseUnit().Code,
+		Factor:    unit.Factor,
+		Aliases:   unit.Aliases,
+	}
+}
+
+// ToUnitResponseList converts a slice of Units to a slice of UnitResponse DTOs
+func ToUnitResponseList(units []models.Unit) []response.UnitResponse {
+	responses := make([]response.UnitResponse, len(units))
+	for i, unit := range units {
+		responses[i] = ToUnitResponse(&unit)
+	}
+	return responses
+}
+
+// ToAttributeRulesResponse converts AttributeRules to AttributeRulesResponse DTO
+func ToAttributeRulesResponse(rules *models.AttributeRules) response.AttributeRulesResponse {
+	resp := response.AttributeRulesResponse{
+		MinValue:  rules.MinValue,
+		MaxValue:  rules.MaxValue,
+		Precision: rules.Precision,
+		MinLength: rules.MinLength,
+		MaxLength: rules.MaxLength,
+		Pattern:   rules.Pattern,
+	}
+	if rules.MinDate != nil {
+		resp.MinDate = rules.MinDate.Format("2006-01-02")
+	}
+	if rules.MaxDate != nil {
+		resp.MaxDate = rules.MaxDate.Format("2006-01-02")
+	}
+	return resp
+}
+
+// ToAttributeOptionResponse converts an AttributeOption model to AttributeOptionResponse DTO
+func ToAttributeOptionResponse(option *models.AttributeOption) response.AttributeOptionResponse {
+	return response.AttributeOptionResponse{
+		ID:          option.ID,
+		AttributeID: option.AttributeID,
+		Code:        option.Code,
+		Label:       option.Label,
+		Sequence:    option.Sequence,
+		CreatedAt:   option.CreatedAt,
+		UpdatedAt:   option.UpdatedAt,
+	}
+}
+
+// ToAttributeOptionResponseList converts a slice of AttributeOption models to a slice of
+// AttributeOptionResponse DTOs, keeping nil as nil
+func ToAttributeOptionResponseList(options []models.AttributeOption) []response.AttributeOptionResponse {
+	if options == nil {
+		return nil
+	}
+	responses := make([]response.AttributeOptionResponse, len(options))
+	for i, option := range options {
+		responses[i] = ToAttributeOptionResponse(&option)
+	}
+	return responses
+}
+
+// ToAttributeResponseList converts a slice of Attribute models to a slice of AttributeResponse DTOs
+func ToAttributeResponseList(attributes []models.Attribute) []response.AttributeResponse {
+	responses := make([]response.AttributeResponse, len(attributes))
+	for i, attribute := range attributes {
+		responses[i] = ToAttributeResponse(&attribute)
+	}
+	return responses
+}
+
+// ToDataTypeChangeResponse converts a DataTypeChangeReport to DataTypeChangeResponse DTO
+func ToDataTypeChangeResponse(report *models.DataTypeChangeReport) response.DataTypeChangeResponse {
+	incompatible := make([]response.IncompatibleValueResponse, len(report.Incompatible))
+	for i, value := range report.Incompatible {
+		incompatible[i] = response.IncompatibleValueResponse{
+			SkuAttributeValueID: value.SkuAttributeValueID,
+			SkuID:               value.SkuID,
+			SkuNumber:           value.SkuNumber,
+			Value:               value.Value,
+			Error:               value.Error,
+		}
+	}
+
+	return response.DataTypeChangeResponse{
+		AttributeID:       report.AttributeID,
+		From:              string(report.From),
+		To:                string(report.To),
+		CheckedCount:      report.CheckedCount,
+		IncompatibleCount: report.IncompatibleCount,
+		Incompatible:      incompatible,
+	}
+}
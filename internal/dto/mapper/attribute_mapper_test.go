@@ -0,0 +1,118 @@
+package mapper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestToAttributeResponse(t *testing.T) {
+	// Setup
+	attribute := &models.Attribute{
+		Base:        models.Base{Model: gorm.Model{ID: 1}, IsActive: true},
+		Name:        "RAM",
+		Code:        "ram",
+		DataType:    models.DataTypeNumber,
+		UOM:         "GB",
+		MultiValued: true,
+	}
+
+	// Execute
+	response := ToAttributeResponse(attribute)
+
+	// Assert
+	assert.Equal(t, uint(1), response.ID)
+	assert.Equal(t, "RAM", response.Name)
+	assert.Equal(t, "ram", response.Code)
+	assert.Equal(t, "NUMBER", response.DataType)
+	assert.Equal(t, "GB", response.UOM)
+	assert.Equal(t, "storage", response.Dimension)
+	assert.True(t, response.MultiValued)
+	assert.True(t, response.IsActive)
+}
+
+func TestToDataTypeChangeResponse(t *testing.T) {
+	// Setup
+	report := &models.DataTypeChangeReport{
+		AttributeID:       1,
+		From:              models.DataTypeText,
+		To:                models.DataTypeNumber,
+		CheckedCount:      10,
+		IncompatibleCount: 1,
+		Incompatible: []models.IncompatibleValue{
+			{SkuAttributeValueID: 5, SkuID: 2, SkuNumber: "LAP-B", Value: "Large", Error: "invalid syntax"},
+		},
+	}
+
+	// Execute
+	response := ToDataTypeChangeResponse(report)
+
+	// Assert
+	assert.Equal(t, "TEXT", response.From)
+	assert.Equal(t, "NUMBER", response.To)
+	assert.Equal(t, int64(10), response.CheckedCount)
+	assert.Equal(t, int64(1), response.IncompatibleCount)
+	assert.Len(t, response.Incompatible, 1)
+	assert.Equal(t, "LAP-B", response.Incompatible[0].SkuNumber)
+	assert.Equal(t, "invalid syntax", response.Incompatible[0].Error)
+}
+
+func TestToAttributeResponseWithOptions(t *testing.T) {
+	// Setup
+	attribute := &models.Attribute{
+		Base:     models.Base{Model: gorm.Model{ID: 3}},
+		Name:     "Color",
+		Code:     "color",
+		DataType: models.DataTypeOption,
+		Options: []models.AttributeOption{
+			{Base: models.Base{Model: gorm.Model{ID: 10}, Sequence: 1}, AttributeID: 3, Code: "red", Label: "Red"},
+		},
+	}
+
+	// Execute
+	response := ToAttributeResponse(attribute)
+
+	// Assert
+	assert.Equal(t, "OPTION", response.DataType)
+	assert.Len(t, response.Options, 1)
+	assert.Equal(t, uint(10), response.Options[0].ID)
+	assert.Equal(t, uint(3), response.Options[0].AttributeID)
+	assert.Equal(t, "red", response.Options[0].Code)
+	assert.Equal(t, "Red", response.Options[0].Label)
+	assert.Equal(t, uint(1), response.Options[0].Sequence)
+	assert.Nil(t, ToAttributeResponse(&models.Attribute{DataType: models.DataTypeText}).Options)
+}
+
+func TestToAttributeRulesResponse(t *testing.T) {
+	// Setup
+	maxLength := 50
+	minDate := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	rules := &models.AttributeRules{MaxLength: &maxLength, Pattern: "[a-z]+", MinDate: &minDate}
+
+	// Execute
+	response := ToAttributeRulesResponse(rules)
+
+	// Assert
+	assert.Equal(t, &maxLength, response.MaxLength)
+	assert.Equal(t, "[a-z]+", response.Pattern)
+	assert.Equal(t, "2020-01-01", response.MinDate)
+	assert.Empty(t, response.MaxDate)
+	assert.Nil(t, response.MinValue)
+}
+
+func TestToUnitResponse(t *testing.T) {
+	// Setup
+	unit, _ := models.LookupUnit("TB")
+
+	// Execute
+	response := ToUnitResponse(unit)
+
+	// Assert
+	assert.Equal(t, "TB", response.Code)
+	assert.Equal(t, "storage", response.Dimension)
+	assert.Equal(t, "B", response.BaseUnit)
+	assert.Equal(t, "1000000000000", response.Factor)
+}
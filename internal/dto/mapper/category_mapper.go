@@ -11,8 +11,11 @@ func ToCategoryResponse(category *models.Category) response.CategoryResponse {
 		ID:          category.ID,
 		Name:        category.Name,
 		Slug:        category.Slug,
+		SlugPinned:  category.SlugPinned,
+		FullPath:    category.FullPath,
 		Description: category.Description,
 		ParentID:    category.ParentID,
+		IsActive:    category.IsActive,
 		CreatedAt:   category.CreatedAt,
 		UpdatedAt:   category.UpdatedAt,
 	}
@@ -24,8 +27,11 @@ func ToCategoryDetailResponse(category *models.Category) response.CategoryDetail
 		ID:          category.ID,
 		Name:        category.Name,
 		Slug:        category.Slug,
+		SlugPinned:  category.SlugPinned,
+		FullPath:    category.FullPath,
 		Description: category.Description,
 		ParentID:    category.ParentID,
+		IsActive:    category.IsActive,
 		CreatedAt:   category.CreatedAt,
 		UpdatedAt:   category.UpdatedAt,
 	}
@@ -45,8 +51,10 @@ func ToCategoryWithChildrenResponse(category *models.Category) response.Category
 		ID:          category.ID,
 		Name:        category.Name,
 		Slug:        category.Slug,
+		FullPath:    category.FullPath,
 		Description: category.Description,
 		ParentID:    category.ParentID,
+		IsActive:    category.IsActive,
 		Children:    []response.CategoryWithChildrenResponse{},
 		CreatedAt:   category.CreatedAt,
 		UpdatedAt:   category.UpdatedAt,
@@ -65,9 +73,24 @@ func ToCategoryWithChildrenResponse(category *models.Category) response.Category
 // ToSimpleCategoryResponse converts a Category model to SimpleCategoryResponse DTO
 func ToSimpleCategoryResponse(category *models.Category) response.SimpleCategoryResponse {
 	return response.SimpleCategoryResponse{
-		ID:   category.ID,
-		Name: category.Name,
-		Slug: category.Slug,
+		ID:       category.ID,
+		Name:     category.Name,
+		Slug:     category.Slug,
+		FullPath: category.FullPath,
+	}
+}
+
+// ToCategoryPathResponse converts a category and its ancestors (root first) to CategoryPathResponse
+func ToCategoryPathResponse(category *models.Category, ancestors []models.Category) response.CategoryPathResponse {
+	breadcrumbs := make([]response.SimpleCategoryResponse, 0, len(ancestors)+1)
+	for _, ancestor := range ancestors {
+		breadcrumbs = append(breadcrumbs, ToSimpleCategoryResponse(&ancestor))
+	}
+	breadcrumbs = append(breadcrumbs, ToSimpleCategoryResponse(category))
+
+	return response.CategoryPathResponse{
+		Category:    ToCategoryDetailResponse(category),
+		Breadcrumbs: breadcrumbs,
 	}
 }
 
@@ -101,3 +124,21 @@ func ToCategoryTreeResponse(categories []models.Category) response.CategoryTreeR
 	}
 }
 
+// ToCategoryTreeResponseWithCounts converts root categories with children to CategoryTreeResponse,
+// setting ProductCount on every node from counts (keyed by category ID)
+func ToCategoryTreeResponseWithCounts(categories []models.Category, counts map[uint]int64) response.CategoryTreeResponse {
+	tree := ToCategoryTreeResponse(categories)
+	for i := range tree.Categories {
+		setProductCounts(&tree.Categories[i], counts)
+	}
+	return tree
+}
+
+// setProductCounts sets ProductCount on node and its descendants
+func setProductCounts(node *response.CategoryWithChildrenResponse, counts map[uint]int64) {
+	count := counts[node.ID]
+	node.ProductCount = &count
+	for i := range node.Children {
+		setProductCounts(&node.Children[i], counts)
+	}
+}
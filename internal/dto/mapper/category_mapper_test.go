@@ -6,6 +6,7 @@ import (
 
 	"github.com/Wilson1510/klampis-pim-go/internal/models"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 )
 
 func TestToCategoryResponse(t *testing.T) {
@@ -17,6 +18,7 @@ func TestToCategoryResponse(t *testing.T) {
 			ID:        2,
 			CreatedAt: now,
 			UpdatedAt: now,
+			IsActive:  true,
 		},
 		Name:        "Laptops",
 		Slug:        "laptops",
@@ -33,6 +35,7 @@ func TestToCategoryResponse(t *testing.T) {
 	assert.Equal(t, "laptops", response.Slug)
 	assert.Equal(t, "Laptop computers", response.Description)
 	assert.Equal(t, &parentID, response.ParentID)
+	assert.True(t, response.IsActive)
 	assert.Equal(t, now, response.CreatedAt)
 	assert.Equal(t, now, response.UpdatedAt)
 }
@@ -257,3 +260,57 @@ func TestToCategoryTreeResponse(t *testing.T) {
 	assert.Equal(t, "Laptops", response.Categories[0].Children[0].Name)
 }
 
+func TestToCategoryTreeResponseWithCounts(t *testing.T) {
+	parentID := uint(1)
+	child := models.Category{Base: models.Base{Model: gorm.Model{ID: 2}}, Name: "Laptops", ParentID: &parentID}
+	categories := []models.Category{
+		{Base: models.Base{Model: gorm.Model{ID: 1}}, Name: "Electronics", Children: []models.Category{child}},
+		{Base: models.Base{Model: gorm.Model{ID: 3}}, Name: "Books"},
+	}
+
+	response := ToCategoryTreeResponseWithCounts(categories, map[uint]int64{1: 7, 2: 4})
+
+	assert.Len(t, response.Categories, 2)
+	if assert.NotNil(t, response.Categories[0].ProductCount) {
+		assert.Equal(t, int64(7), *response.Categories[0].ProductCount)
+	}
+	if assert.NotNil(t, response.Categories[0].Children[0].ProductCount) {
+		assert.Equal(t, int64(4), *response.Categories[0].Children[0].ProductCount)
+	}
+	// Categories without products report zero rather than omitting the count
+	if assert.NotNil(t, response.Categories[1].ProductCount) {
+		assert.Equal(t, int64(0), *response.Categories[1].ProductCount)
+	}
+
+	assert.Nil(t, ToCategoryTreeResponse(categories).Categories[0].ProductCount)
+}
+
+func TestToCategoryPathResponse(t *testing.T) {
+	rootID := uint(1)
+	ancestors := []models.Category{
+		{Base: models.Base{Model: gorm.Model{ID: 1}}, Name: "Electronics", Slug: "electronics", FullPath: "electronics"},
+	}
+	category := models.Category{
+		Base:     models.Base{Model: gorm.Model{ID: 4}},
+		Name:     "Laptops",
+		Slug:     "laptops",
+		FullPath: "electronics/laptops",
+		ParentID: &rootID,
+		Parent:   &ancestors[0],
+	}
+
+	response := ToCategoryPathResponse(&category, ancestors)
+
+	assert.Equal(t, "electronics/laptops", response.Category.FullPath)
+	if assert.NotNil(t, response.Category.Parent) {
+		assert.Equal(t, "electronics", response.Category.Parent.FullPath)
+	}
+	if assert.Len(t, response.Breadcrumbs, 2) {
+		assert.Equal(t, "Electronics", response.Breadcrumbs[0].Name)
+		assert.Equal(t, "electronics/laptops", response.Breadcrumbs[1].FullPath)
+	}
+
+	// A root category is its only breadcrumb
+	root := ToCategoryPathResponse(&ancestors[0], nil)
+	assert.Len(t, root.Breadcrumbs, 1)
+}
@@ -0,0 +1,26 @@
+package mapper
+
+import (
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/response"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+)
+
+// ToImageResponse converts an Image model to ImageResponse DTO
+func ToImageResponse(image *models.Image) response.ImageResponse {
+	return response.ImageResponse{
+		ID:        image.ID,
+		File:      image.File,
+		Title:     image.Title,
+		IsPrimary: image.IsPrimary,
+		CreatedAt: image.CreatedAt,
+	}
+}
+
+// ToImageResponseList converts a slice of Image models to a slice of ImageResponse DTOs
+func ToImageResponseList(images []models.Image) []response.ImageResponse {
+	responses := make([]response.ImageResponse, len(images))
+	for i, image := range images {
+		responses[i] = ToImageResponse(&image)
+	}
+	return responses
+}
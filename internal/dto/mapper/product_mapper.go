@@ -0,0 +1,72 @@
+package mapper
+
+import (
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/response"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+)
+
+// ToProductResponse converts a Product model to ProductResponse DTO
+func ToProductResponse(product *models.Product) response.ProductResponse {
+	resp := response.ProductResponse{
+		ID:          product.ID,
+		Name:        product.Name,
+		Slug:        product.Slug,
+		SlugPinned:  product.SlugPinned,
+		Description: product.Description,
+		CategoryID:  product.CategoryID,
+		IsActive:    product.IsActive,
+		CreatedAt:   product.CreatedAt,
+		UpdatedAt:   product.UpdatedAt,
+	}
+
+	// Include category if it was preloaded
+	if product.Category != nil {
+		categoryResp := ToSimpleCategoryResponse(product.Category)
+		resp.Category = &categoryResp
+	}
+
+	return resp
+}
+
+// ToProductDetailResponse converts a Product model with its relations to ProductDetailResponse DTO
+func ToProductDetailResponse(product *models.Product) response.ProductDetailResponse {
+	resp := response.ProductDetailResponse{
+		ID:          product.ID,
+		Name:        product.Name,
+		Slug:        product.Slug,
+		SlugPinned:  product.SlugPinned,
+		Description: product.Description,
+		CategoryID:  product.CategoryID,
+		Images:      ToImageResponseList(product.Images),
+		Skus:        ToSkuResponseList(product.Skus),
+		IsActive:    product.IsActive,
+		CreatedAt:   product.CreatedAt,
+		UpdatedAt:   product.UpdatedAt,
+	}
+
+	// Include category if it was preloaded
+	if product.Category != nil {
+		categoryResp := ToSimpleCategoryResponse(product.Category)
+		resp.Category = &categoryResp
+	}
+
+	return resp
+}
+
+// ToSimpleProductResponse converts a Product model to SimpleProductResponse DTO
+func ToSimpleProductResponse(product *models.Product) response.SimpleProductResponse {
+	return response.SimpleProductResponse{
+		ID:   product.ID,
+		Name: product.Name,
+		Slug: product.Slug,
+	}
+}
+
+// ToProductResponseList converts a slice of Product models to a slice of ProductResponse DTOs
+func ToProductResponseList(products []models.Product) []response.ProductResponse {
+	responses := make([]response.ProductResponse, len(products))
+	for i, product := range products {
+		responses[i] = ToProductResponse(&product)
+	}
+	return responses
+}
@@ -0,0 +1,128 @@
+package mapper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestToProductResponse(t *testing.T) {
+	// Setup
+	now := time.Now()
+	product := &models.Product{
+		Base: models.Base{
+			Model: gorm.Model{ID: 123, CreatedAt: now, UpdatedAt: now},
+		},
+		Name:        "ASUS ROG Strix G15",
+		Slug:        "asus-rog-strix-g15",
+		Description: "Gaming laptop",
+		CategoryID:  5,
+	}
+
+	// Execute
+	response := ToProductResponse(product)
+
+	// Assert
+	assert.Equal(t, uint(123), response.ID)
+	assert.Equal(t, "ASUS ROG Strix G15", response.Name)
+	assert.Equal(t, "asus-rog-strix-g15", response.Slug)
+	assert.Equal(t, "Gaming laptop", response.Description)
+	assert.Equal(t, uint(5), response.CategoryID)
+	assert.Equal(t, now, response.CreatedAt)
+	assert.Equal(t, now, response.UpdatedAt)
+}
+
+func TestToProductResponseList(t *testing.T) {
+	// Setup
+	products := []models.Product{
+		{Base: models.Base{Model: gorm.Model{ID: 1}}, Name: "Laptop"},
+		{Base: models.Base{Model: gorm.Model{ID: 2}}, Name: "Phone"},
+	}
+
+	// Execute
+	responses := ToProductResponseList(products)
+
+	// Assert
+	assert.Len(t, responses, 2)
+	assert.Equal(t, "Laptop", responses[0].Name)
+	assert.Equal(t, "Phone", responses[1].Name)
+}
+
+func TestToProductResponseWithCategory(t *testing.T) {
+	// Setup
+	product := &models.Product{
+		Base:       models.Base{Model: gorm.Model{ID: 1}},
+		Name:       "Laptop",
+		CategoryID: 5,
+		Category: &models.Category{
+			Base: models.Base{Model: gorm.Model{ID: 5}},
+			Name: "Laptops",
+			Slug: "laptops",
+		},
+	}
+
+	// Execute
+	response := ToProductResponse(product)
+
+	// Assert
+	assert.NotNil(t, response.Category)
+	assert.Equal(t, uint(5), response.Category.ID)
+	assert.Equal(t, "Laptops", response.Category.Name)
+	assert.Equal(t, "laptops", response.Category.Slug)
+}
+
+func TestToProductDetailResponse(t *testing.T) {
+	// Setup
+	product := &models.Product{
+		Base:       models.Base{Model: gorm.Model{ID: 123}},
+		Name:       "ASUS ROG Strix G15",
+		Slug:       "asus-rog-strix-g15",
+		CategoryID: 5,
+		Category: &models.Category{
+			Base: models.Base{Model: gorm.Model{ID: 5}},
+			Name: "Laptops",
+			Slug: "laptops",
+		},
+		Images: []models.Image{
+			{Base: models.Base{Model: gorm.Model{ID: 501}}, File: "/uploads/front.jpg", Title: "Front View", IsPrimary: true},
+		},
+		Skus: []models.Sku{
+			{Base: models.Base{Model: gorm.Model{ID: 1001}}, Name: "16GB/512GB", SkuNumber: "ASUS-ROG-G15-001", Price: 15000000, ProductID: 123},
+			{Base: models.Base{Model: gorm.Model{ID: 1002}}, Name: "32GB/1TB", SkuNumber: "ASUS-ROG-G15-002", Price: 20000000, ProductID: 123},
+		},
+	}
+
+	// Execute
+	response := ToProductDetailResponse(product)
+
+	// Assert
+	assert.Equal(t, uint(123), response.ID)
+	assert.Equal(t, "Laptops", response.Category.Name)
+	assert.Len(t, response.Images, 1)
+	assert.Equal(t, "/uploads/front.jpg", response.Images[0].File)
+	assert.True(t, response.Images[0].IsPrimary)
+	assert.Len(t, response.Skus, 2)
+	assert.Equal(t, "ASUS-ROG-G15-002", response.Skus[1].SkuNumber)
+	assert.Equal(t, float64(20000000), response.Skus[1].Price)
+}
+
+func TestToProductDetailResponseWithoutRelations(t *testing.T) {
+	// Setup
+	product := &models.Product{
+		Base: models.Base{Model: gorm.Model{ID: 1}},
+		Name: "Laptop",
+	}
+
+	// Execute
+	response := ToProductDetailResponse(product)
+
+	// Assert
+	assert.Nil(t, response.Category)
+	assert.NotNil(t, response.Images)
+	assert.Empty(t, response.Images)
+	assert.NotNil(t, response.Skus)
+	assert.Empty(t, response.Skus)
+}
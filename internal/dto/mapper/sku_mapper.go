@@ -0,0 +1,108 @@
+package mapper
+
+import (
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/response"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+)
+
+// ToSkuResponse converts a Sku model to SkuResponse DTO
+func ToSkuResponse(sku *models.Sku) response.SkuResponse {
+	return response.SkuResponse{
+		ID:          sku.ID,
+		Name:        sku.Name,
+		Slug:        sku.Slug,
+		SlugPinned:  sku.SlugPinned,
+		Description: sku.Description,
+		SkuNumber:   sku.SkuNumber,
+		Price:       sku.Price,
+		ProductID:   sku.ProductID,
+		IsActive:    sku.IsActive,
+		CreatedAt:   sku.CreatedAt,
+		UpdatedAt:   sku.UpdatedAt,
+	}
+}
+
+// ToSkuDetailResponse converts a Sku model with its relations to SkuDetailResponse DTO
+func ToSkuDetailResponse(sku *models.Sku) response.SkuDetailResponse {
+	resp := response.SkuDetailResponse{
+		ID:          sku.ID,
+		Name:        sku.Name,
+		Slug:        sku.Slug,
+		SlugPinned:  sku.SlugPinned,
+		Description: sku.Description,
+		SkuNumber:   sku.SkuNumber,
+		Price:       sku.Price,
+		ProductID:   sku.ProductID,
+		Images:      ToImageResponseList(sku.Images),
+		Attributes:  ToSkuAttributeValueResponseList(sku.AttributeValues),
+		IsActive:    sku.IsActive,
+		CreatedAt:   sku.CreatedAt,
+		UpdatedAt:   sku.UpdatedAt,
+	}
+
+	// Include product if it was preloaded
+	if sku.Product != nil {
+		productResp := ToSimpleProductResponse(sku.Product)
+		resp.Product = &productResp
+	}
+
+	return resp
+}
+
+// ToSkuAttributeValueResponse converts a SkuAttributeValue model to SkuAttributeValueResponse DTO
+func ToSkuAttributeValueResponse(value *models.SkuAttributeValue) response.SkuAttributeValueResponse {
+	resp := response.SkuAttributeValueResponse{
+		ID:          value.ID,
+		AttributeID: value.AttributeID,
+		Value:       value.Value,
+		Position:    value.Position,
+		BaseValue:   value.BaseValue,
+		Sequence:    value.Sequence,
+	}
+	if value.PossiblyRounded != nil {
+		resp.PossiblyRounded = *value.PossiblyRounded
+	}
+
+	// Join attribute details if the attribute was preloaded
+	if value.Attribute != nil {
+		resp.AttributeName = value.Attribute.Name
+		resp.AttributeCode = value.Attribute.Code
+		resp.UOM = value.Attribute.UOM
+		if option, ok := value.Attribute.FindOption(value.Value); ok && value.Attribute.DataType == models.DataTypeOption {
+			resp.Label = option.Label
+		}
+		if value.DisplayUnit != nil {
+			resp.DisplayValue = value.Attribute.DisplayValuesIn(value.DisplayUnit, value.Value)
+		} else {
+			resp.DisplayValue = value.Attribute.DisplayValues(value.Value)
+		}
+	}
+
+	return resp
+}
+
+// ToSkuAttributesResponse converts the attribute values of a SKU to SkuAttributesResponse DTO
+func ToSkuAttributesResponse(skuID uint, values []models.SkuAttributeValue) response.SkuAttributesResponse {
+	return response.SkuAttributesResponse{
+		SkuID:      skuID,
+		Attributes: ToSkuAttributeValueResponseList(values),
+	}
+}
+
+// ToSkuResponseList converts a slice of Sku models to a slice of SkuResponse DTOs
+func ToSkuResponseList(skus []models.Sku) []response.SkuResponse {
+	responses := make([]response.SkuResponse, len(skus))
+	for i, sku := range skus {
+		responses[i] = ToSkuResponse(&sku)
+	}
+	return responses
+}
+
+// ToSkuAttributeValueResponseList converts a slice of SkuAttributeValue models to a slice of SkuAttributeValueResponse DTOs
+func ToSkuAttributeValueResponseList(values []models.SkuAttributeValue) []response.SkuAttributeValueResponse {
+	responses := make([]response.SkuAttributeValueResponse, len(values))
+	for i, value := range values {
+		responses[i] = ToSkuAttributeValueResponse(&value)
+	}
+	return responses
+}
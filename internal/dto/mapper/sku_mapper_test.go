@@ -0,0 +1,84 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestToSkuDetailResponse(t *testing.T) {
+	// Setup
+	sku := &models.Sku{
+		Base:      models.Base{Model: gorm.Model{ID: 1001}},
+		Name:      "ASUS ROG Strix G15 - 16GB/512GB",
+		SkuNumber: "ASUS-ROG-G15-001",
+		Price:     15000000,
+		ProductID: 123,
+		Product: &models.Product{
+			Base: models.Base{Model: gorm.Model{ID: 123}},
+			Name: "ASUS ROG Strix G15",
+			Slug: "asus-rog-strix-g15",
+		},
+		AttributeValues: []models.SkuAttributeValue{
+			{
+				Model:       gorm.Model{ID: 1},
+				AttributeID: 7,
+				Value:       "16",
+				Sequence:    1,
+				Attribute:   &models.Attribute{Name: "RAM", Code: "ram", UOM: "GB"},
+			},
+		},
+	}
+
+	// Execute
+	response := ToSkuDetailResponse(sku)
+
+	// Assert
+	assert.Equal(t, uint(1001), response.ID)
+	assert.Equal(t, "ASUS-ROG-G15-001", response.SkuNumber)
+	assert.NotNil(t, response.Product)
+	assert.Equal(t, "asus-rog-strix-g15", response.Product.Slug)
+	assert.Empty(t, response.Images)
+	assert.Len(t, response.Attributes, 1)
+	assert.Equal(t, uint(7), response.Attributes[0].AttributeID)
+	assert.Equal(t, "RAM", response.Attributes[0].AttributeName)
+	assert.Equal(t, "ram", response.Attributes[0].AttributeCode)
+	assert.Equal(t, "GB", response.Attributes[0].UOM)
+	assert.Equal(t, "16", response.Attributes[0].Value)
+	assert.Equal(t, "16 GB", response.Attributes[0].DisplayValue)
+}
+
+func TestToSkuAttributeValueResponseWithoutAttribute(t *testing.T) {
+	// Setup
+	value := &models.SkuAttributeValue{
+		Model:       gorm.Model{ID: 1},
+		AttributeID: 7,
+		Value:       "16",
+	}
+
+	// Execute
+	response := ToSkuAttributeValueResponse(value)
+
+	// Assert
+	assert.Equal(t, uint(7), response.AttributeID)
+	assert.Equal(t, "", response.AttributeName)
+	assert.Equal(t, "16", response.Value)
+}
+
+func TestToSkuAttributesResponse(t *testing.T) {
+	// Setup
+	values := []models.SkuAttributeValue{
+		{AttributeID: 1, Value: "16"},
+		{AttributeID: 2, Value: "512"},
+	}
+
+	// Execute
+	response := ToSkuAttributesResponse(123, values)
+
+	// Assert
+	assert.Equal(t, uint(123), response.SkuID)
+	assert.Len(t, response.Attributes, 2)
+	assert.Equal(t, "512", response.Attributes[1].Value)
+}
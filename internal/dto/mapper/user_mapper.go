@@ -0,0 +1,27 @@
+package mapper
+
+import (
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/response"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+)
+
+// ToUserResponse converts a User model to UserResponse DTO
+func ToUserResponse(user *models.User) response.UserResponse {
+	return response.UserResponse{
+		ID:        user.ID,
+		Username:  user.Username,
+		Name:      user.Name,
+		Role:      string(user.Role),
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
+
+// ToUserResponseList converts a slice of User models to a slice of UserResponse DTOs
+func ToUserResponseList(users []models.User) []response.UserResponse {
+	responses := make([]response.UserResponse, len(users))
+	for i, user := range users {
+		responses[i] = ToUserResponse(&user)
+	}
+	return responses
+}
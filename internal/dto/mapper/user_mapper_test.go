@@ -0,0 +1,45 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestToUserResponse(t *testing.T) {
+	// Setup
+	user := &models.User{
+		Model:    gorm.Model{ID: 2},
+		Username: "jdoe",
+		Password: "$2a$12$hash",
+		Name:     "John Doe",
+		Role:     models.RoleUser,
+	}
+
+	// Execute
+	response := ToUserResponse(user)
+
+	// Assert
+	assert.Equal(t, uint(2), response.ID)
+	assert.Equal(t, "jdoe", response.Username)
+	assert.Equal(t, "John Doe", response.Name)
+	assert.Equal(t, "USER", response.Role)
+}
+
+func TestToUserResponseList(t *testing.T) {
+	// Setup
+	users := []models.User{
+		{Model: gorm.Model{ID: 1}, Username: "system", Role: models.RoleSystem},
+		{Model: gorm.Model{ID: 2}, Username: "jdoe", Role: models.RoleUser},
+	}
+
+	// Execute
+	responses := ToUserResponseList(users)
+
+	// Assert
+	assert.Len(t, responses, 2)
+	assert.Equal(t, "system", responses[0].Username)
+	assert.Equal(t, "jdoe", responses[1].Username)
+}
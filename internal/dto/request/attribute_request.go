@@ -0,0 +1,80 @@
+package request
+
+// CreateAttributeRequest represents the request body for creating a new attribute
+type CreateAttributeRequest struct {
+	Name     string `json:"name" binding:"required,min=1,max=50" example:"RAM"`
+	Code     string `json:"code" binding:"required,min=1,max=70" example:"ram"`
+	DataType string `json:"data_type" binding:"required,oneof=TEXT NUMBER BOOLEAN DATE OPTION" example:"NUMBER"`
+	UOM      string `json:"uom" binding:"omitempty,max=15" example:"GB"`
+	// MultiValued lets a SKU hold an ordered list of values for the attribute
+	MultiValued bool                   `json:"multi_valued" example:"false"`
+	Rules       *AttributeRulesRequest `json:"rules"`
+	IsActive    *bool                  `json:"is_active" binding:"omitempty" example:"true"`
+}
+
+// UpdateAttributeRequest represents the request body for updating an existing attribute
+type UpdateAttributeRequest struct {
+	Name     *string `json:"name" binding:"omitempty,min=1,max=50" example:"RAM"`
+	Code     *string `json:"code" binding:"omitempty,min=1,max=70" example:"ram"`
+	DataType *string `json:"data_type" binding:"omitempty,oneof=TEXT NUMBER BOOLEAN DATE OPTION" example:"NUMBER"`
+	UOM      *string `json:"uom" binding:"omitempty,max=15" example:"GB"`
+	// RelabelValues keeps the stored numbers as they are when the new UOM cannot
+	// be converted to, e.g. because it measures another dimension
+	RelabelValues bool `json:"relabel_values" binding:"omitempty" example:"false"`
+	// Switching MultiValued off is rejected while a SKU holds more than one value
+	MultiValued *bool `json:"multi_valued" binding:"omitempty" example:"false"`
+	// Rules replace the whole rule set; send an empty object to remove every rule
+	Rules    *AttributeRulesRequest `json:"rules"`
+	IsActive *bool                  `json:"is_active" binding:"omitempty" example:"true"`
+}
+
+// AttributeRulesRequest represents the validation rules of an attribute. Each rule
+// applies to one data type: min_value, max_value and precision to NUMBER,
+// min_length, max_length and pattern to TEXT, min_date and max_date to DATE.
+type AttributeRulesRequest struct {
+	MinValue  *float64 `json:"min_value" example:"10"`
+	MaxValue  *float64 `json:"max_value" example:"18"`
+	Precision *int     `json:"precision" binding:"omitempty,min=0,max=10" example:"1"` // Maximum number of decimal places
+	MinLength *int     `json:"min_length" binding:"omitempty,min=0" example:"1"`
+	MaxLength *int     `json:"max_length" binding:"omitempty,min=1" example:"50"`
+	Pattern   string   `json:"pattern" binding:"omitempty,max=255" example:"[A-Z]{2}-[0-9]+"` // Has to match the whole value
+	MinDate   string   `json:"min_date" binding:"omitempty,datetime=2006-01-02" example:"2020-01-01"`
+	MaxDate   string   `json:"max_date" binding:"omitempty,datetime=2006-01-02" example:"2030-12-31"`
+}
+
+// UpdateAttributeOptions represents query parameters accepted when updating an attribute
+type UpdateAttributeOptions struct {
+	// Preview the impact of a data type change without saving anything
+	DryRun bool `form:"dry_run" binding:"omitempty" example:"false"`
+}
+
+// GetAttributesRequest represents query parameters for listing attributes
+type GetAttributesRequest struct {
+	PaginationRequest
+	Name     string `form:"name" binding:"omitempty,max=50" example:"RAM"`
+	Code     string `form:"code" binding:"omitempty,max=70" example:"ram"`
+	DataType string `form:"data_type" binding:"omitempty,oneof=TEXT NUMBER BOOLEAN DATE OPTION" example:"NUMBER"`
+}
+
+// CreateAttributeOptionRequest represents the request body for adding an option to an attribute
+type CreateAttributeOptionRequest struct {
+	Code     string `json:"code" binding:"required,min=1,max=70" example:"red"`
+	Label    string `json:"label" binding:"required,min=1,max=100" example:"Red"`
+	Sequence *uint  `json:"sequence" binding:"omitempty" example:"1"`
+}
+
+// UpdateAttributeOptionRequest represents the request body for updating an attribute option.
+// Changing the code also rewrites the SKU values that use the option.
+type UpdateAttributeOptionRequest struct {
+	Code     *string `json:"code" binding:"omitempty,min=1,max=70" example:"red"`
+	Label    *string `json:"label" binding:"omitempty,min=1,max=100" example:"Red"`
+	Sequence *uint   `json:"sequence" binding:"omitempty" example:"1"`
+}
+
+// MergeAttributeOptionsRequest represents the request body for merging duplicate options into one
+type MergeAttributeOptionsRequest struct {
+	// Options that are removed after their SKU values are moved to the target
+	SourceOptionIDs []uint `json:"source_option_ids" binding:"required,min=1,dive,min=1" example:"7,9"`
+	// Option that is kept
+	TargetOptionID uint `json:"target_option_id" binding:"required,min=1" example:"3"`
+}
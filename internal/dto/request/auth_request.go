@@ -0,0 +1,18 @@
+package request
+
+// LoginRequest represents the request body for logging in
+type LoginRequest struct {
+	Username string `json:"username" binding:"required,max=50" example:"admin"`
+	Password string `json:"password" binding:"required,max=255" example:"secret"`
+}
+
+// RefreshTokenRequest represents the request body for exchanging a refresh token
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required" example:"q0Yl7c3m..."`
+}
+
+// LogoutRequest represents the request body for logging out
+type LogoutRequest struct {
+	// Optional: also revoke this refresh token and every token rotated from it
+	RefreshToken string `json:"refresh_token" binding:"omitempty" example:"q0Yl7c3m..."`
+}
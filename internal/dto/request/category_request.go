@@ -2,9 +2,10 @@ package request
 
 // CreateCategoryRequest represents the request body for creating a new category
 type CreateCategoryRequest struct {
-	Name        string  `json:"name" binding:"required,min=1,max=100" example:"Electronics"`
-	Description string  `json:"description" binding:"omitempty" example:"All electronic products"`
-	ParentID    *uint   `json:"parent_id" binding:"omitempty" example:"1"`
+	Name        string `json:"name" binding:"required,min=1,max=100" example:"Electronics"`
+	Description string `json:"description" binding:"omitempty" example:"All electronic products"`
+	ParentID    *uint  `json:"parent_id" binding:"omitempty" example:"1"`
+	IsActive    *bool  `json:"is_active" binding:"omitempty" example:"true"`
 }
 
 // UpdateCategoryRequest represents the request body for updating an existing category
@@ -12,6 +13,37 @@ type UpdateCategoryRequest struct {
 	Name        *string `json:"name" binding:"omitempty,min=1,max=100" example:"Electronics"`
 	Description *string `json:"description" binding:"omitempty" example:"All electronic products"`
 	ParentID    *uint   `json:"parent_id" binding:"omitempty" example:"1"`
+	IsActive    *bool   `json:"is_active" binding:"omitempty" example:"true"`
+	// Keep the current slug when the name changes
+	SlugPinned *bool `json:"slug_pinned" binding:"omitempty" example:"true"`
+}
+
+// Placements for the products of a moved or deleted category
+const (
+	// ProductsKeep leaves the products in the moved category
+	ProductsKeep = "keep"
+	// ProductsParent moves the products to the category's previous parent
+	ProductsParent = "parent"
+	// ProductsCategory moves the products to TargetCategoryID
+	ProductsCategory = "category"
+)
+
+// MoveCategoryRequest represents the request body for moving a category with its subtree
+type MoveCategoryRequest struct {
+	// New parent of the category; null or omitted moves it to the root level
+	ParentID *uint `json:"parent_id" binding:"omitempty" example:"1"`
+	// Where the products of the moved category end up (default: keep)
+	Products string `json:"products" binding:"omitempty,oneof=keep parent category" example:"keep"`
+	// Category receiving the products when products is "category"
+	TargetCategoryID *uint `json:"target_category_id" binding:"required_if=Products category" example:"3"`
+}
+
+// DeleteCategoryRequest represents query parameters for deleting a category
+type DeleteCategoryRequest struct {
+	// Where the products of the deleted category end up; without it, a category with products cannot be deleted
+	Products string `form:"products" binding:"omitempty,oneof=parent category" example:"parent"`
+	// Category receiving the products when products is "category"
+	TargetCategoryID *uint `form:"target_category_id" binding:"required_if=Products category" example:"3"`
 }
 
 // GetCategoriesRequest represents query parameters for listing categories
@@ -23,3 +55,21 @@ type GetCategoriesRequest struct {
 	RootOnly bool `form:"root_only" binding:"omitempty" example:"false"`
 }
 
+// GetCategoryProductsRequest represents query parameters for listing the products of a category
+type GetCategoryProductsRequest struct {
+	PaginationRequest
+	// Also include the products of every subcategory, at any depth
+	IncludeSubcategories bool `form:"include_subcategories" binding:"omitempty" example:"true"`
+}
+
+// GetCategoryTreeRequest represents query parameters for loading the category tree
+type GetCategoryTreeRequest struct {
+	// Load the subtree rooted at this category instead of every root category
+	RootID *uint `form:"root_id" binding:"omitempty" example:"1"`
+	// Levels below the roots to include; 0 returns the roots only. Unlimited when omitted.
+	MaxDepth *int `form:"max_depth" binding:"omitempty,min=0" example:"2"`
+	// Only include categories with this is_active value. An excluded category hides its subtree.
+	IsActive *bool `form:"is_active" binding:"omitempty" example:"true"`
+	// Include the number of products in each category and all of its descendants
+	IncludeProductCount bool `form:"include_product_count" binding:"omitempty" example:"true"`
+}
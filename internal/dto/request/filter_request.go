@@ -0,0 +1,122 @@
+package request
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// FilterOperator is a comparison accepted in ?filter[field][op]=value
+type FilterOperator string
+
+const (
+	FilterEq   FilterOperator = "eq"
+	FilterNe   FilterOperator = "ne"
+	FilterGt   FilterOperator = "gt"
+	FilterLt   FilterOperator = "lt"
+	FilterGe   FilterOperator = "ge"
+	FilterLe   FilterOperator = "le"
+	FilterLike FilterOperator = "like"
+)
+
+// FilterOperators lists every supported operator
+var FilterOperators = []FilterOperator{FilterEq, FilterNe, FilterGt, FilterLt, FilterGe, FilterLe, FilterLike}
+
+// Filter is a single condition parsed from the query string. The field is not
+// checked here; services validate it against their own whitelist.
+type Filter struct {
+	Field    string
+	Operator FilterOperator
+	Value    string
+}
+
+// FilterParseError describes malformed filter query parameters by parameter name
+type FilterParseError struct {
+	Details map[string]string
+}
+
+// Error implements the error interface
+func (e *FilterParseError) Error() string {
+	return "invalid filter parameters"
+}
+
+// ParseFilters extracts the conditions from filter[field]=value and
+// filter[field][op]=value query parameters. The bare form means eq.
+// Filters are returned sorted by field and operator so queries are deterministic.
+func ParseFilters(query url.Values) ([]Filter, error) {
+	var filters []Filter
+	details := make(map[string]string)
+
+	for key, values := range query {
+		if !strings.HasPrefix(key, "filter[") {
+			continue
+		}
+
+		field, operator, ok := parseFilterKey(key)
+		if !ok {
+			details[key] = "must be filter[field] or filter[field][op]"
+			continue
+		}
+		if !isFilterOperator(operator) {
+			details[key] = fmt.Sprintf("unknown operator %q, allowed operators: %s", operator, joinOperators())
+			continue
+		}
+
+		for _, value := range values {
+			filters = append(filters, Filter{Field: field, Operator: operator, Value: value})
+		}
+	}
+
+	if len(details) > 0 {
+		return nil, &FilterParseError{Details: details}
+	}
+
+	sort.SliceStable(filters, func(i, j int) bool {
+		if filters[i].Field != filters[j].Field {
+			return filters[i].Field < filters[j].Field
+		}
+		return filters[i].Operator < filters[j].Operator
+	})
+	return filters, nil
+}
+
+// parseFilterKey splits filter[field] or filter[field][op] into its parts
+func parseFilterKey(key string) (string, FilterOperator, bool) {
+	rest := strings.TrimPrefix(key, "filter[")
+	field, rest, found := strings.Cut(rest, "]")
+	if !found || field == "" {
+		return "", "", false
+	}
+	if rest == "" {
+		return field, FilterEq, true
+	}
+
+	if !strings.HasPrefix(rest, "[") || !strings.HasSuffix(rest, "]") {
+		return "", "", false
+	}
+	operator := rest[1 : len(rest)-1]
+	if operator == "" || strings.ContainsAny(operator, "[]") {
+		return "", "", false
+	}
+	return field, FilterOperator(operator), true
+}
+
+// isFilterOperator reports whether operator is supported
+func isFilterOperator(operator FilterOperator) bool {
+	for _, supported := range FilterOperators {
+		if operator == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// joinOperators lists the supported operators for error messages
+func joinOperators() string {
+	names := make([]string, len(FilterOperators))
+	for i, operator := range FilterOperators {
+		names[i] = string(operator)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,50 @@
+package request
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilters(t *testing.T) {
+	query := url.Values{
+		"filter[price][gt]": {"100"},
+		"filter[name]":      {"laptop"},
+		"filter[price][le]": {"500"},
+		"page":              {"2"},
+	}
+
+	filters, err := ParseFilters(query)
+
+	require.NoError(t, err)
+	assert.Equal(t, []Filter{
+		{Field: "name", Operator: FilterEq, Value: "laptop"},
+		{Field: "price", Operator: FilterGt, Value: "100"},
+		{Field: "price", Operator: FilterLe, Value: "500"},
+	}, filters)
+}
+
+func TestParseFiltersErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		key  string
+	}{
+		{name: "Unknown operator", key: "filter[price][between]"},
+		{name: "Empty field", key: "filter[]"},
+		{name: "Empty operator", key: "filter[price][]"},
+		{name: "Nested operator", key: "filter[price][gt][x]"},
+		{name: "Unclosed bracket", key: "filter[price"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseFilters(url.Values{tc.key: {"1"}})
+
+			var parseErr *FilterParseError
+			require.ErrorAs(t, err, &parseErr)
+			assert.Contains(t, parseErr.Details, tc.key)
+		})
+	}
+}
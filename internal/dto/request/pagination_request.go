@@ -1,11 +1,27 @@
 package request
 
+import "strings"
+
 // PaginationRequest represents common pagination parameters
 type PaginationRequest struct {
 	Page      int    `form:"page" binding:"omitempty,min=1" example:"1"`
 	Limit     int    `form:"limit" binding:"omitempty,min=1,max=100" example:"20"`
 	SortField string `form:"sort_field" binding:"omitempty" example:"created_at"`
 	OrderRule string `form:"order_rule" binding:"omitempty,oneof=asc desc" example:"asc"`
+	// Comma separated sort keys, "-" prefix for descending. Takes precedence over sort_field/order_rule.
+	Sort string `form:"sort" binding:"omitempty,max=200" example:"-price,name"`
+	// Pagination selects offset (page/limit) or keyset (cursor) pagination
+	Pagination string `form:"pagination" binding:"omitempty,oneof=offset cursor" example:"cursor"`
+	// Opaque cursor from a previous next_cursor/prev_cursor; implies cursor pagination
+	Cursor string `form:"cursor" binding:"omitempty,max=2048" example:"eyJzIjoiaWQiLCJ2IjpbIjQyIl19.q0Yl7c3m"`
+
+	// Filters holds the parsed ?filter[field][op]=value parameters
+	Filters []Filter `form:"-"`
+}
+
+// SetFilters stores the filters parsed from the query string
+func (p *PaginationRequest) SetFilters(filters []Filter) {
+	p.Filters = filters
 }
 
 // GetPage returns the page number with default value of 1
@@ -27,12 +43,18 @@ func (p *PaginationRequest) GetLimit() int {
 	return p.Limit
 }
 
+// IsCursorMode reports whether keyset pagination was requested
+func (p *PaginationRequest) IsCursorMode() bool {
+	return p.Pagination == "cursor" || p.Cursor != ""
+}
+
 // GetOffset calculates the offset for database queries
 func (p *PaginationRequest) GetOffset() int {
 	return (p.GetPage() - 1) * p.GetLimit()
 }
 
-// GetSortField returns the sort field with default value
+// GetSortField returns the sort field with default value.
+// The value comes straight from the client and must be checked against a whitelist before use.
 func (p *PaginationRequest) GetSortField() string {
 	if p.SortField == "" {
 		return "created_at"
@@ -48,3 +70,29 @@ func (p *PaginationRequest) GetOrderRule() string {
 	return p.OrderRule
 }
 
+// SortKey is a single ordering term of a listing
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// GetSortKeys returns the requested ordering. The sort parameter is parsed as
+// comma separated keys such as "-price,name"; without it the legacy
+// sort_field/order_rule pair is used. Fields are not validated here.
+func (p *PaginationRequest) GetSortKeys() []SortKey {
+	if p.Sort == "" {
+		return []SortKey{{Field: p.GetSortField(), Desc: p.GetOrderRule() == "desc"}}
+	}
+
+	var keys []SortKey
+	for _, part := range strings.Split(p.Sort, ",") {
+		part = strings.TrimSpace(part)
+		desc := strings.HasPrefix(part, "-")
+		part = strings.TrimLeft(part, "+-")
+		if part == "" {
+			continue
+		}
+		keys = append(keys, SortKey{Field: part, Desc: desc})
+	}
+	return keys
+}
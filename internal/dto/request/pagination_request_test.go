@@ -0,0 +1,27 @@
+package request
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSortKeys(t *testing.T) {
+	testCases := []struct {
+		name     string
+		req      PaginationRequest
+		expected []SortKey
+	}{
+		{name: "Default", req: PaginationRequest{}, expected: []SortKey{{Field: "created_at"}}},
+		{name: "Legacy sort_field and order_rule", req: PaginationRequest{SortField: "name", OrderRule: "desc"}, expected: []SortKey{{Field: "name", Desc: true}}},
+		{name: "Multiple keys", req: PaginationRequest{Sort: "-price,name"}, expected: []SortKey{{Field: "price", Desc: true}, {Field: "name"}}},
+		{name: "Sort wins over legacy fields", req: PaginationRequest{Sort: "+name", SortField: "price"}, expected: []SortKey{{Field: "name"}}},
+		{name: "Blank keys are skipped", req: PaginationRequest{Sort: " name , ,-attr.ram"}, expected: []SortKey{{Field: "name"}, {Field: "attr.ram", Desc: true}}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.req.GetSortKeys())
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package request
+
+// CreateProductRequest represents the request body for creating a new product
+type CreateProductRequest struct {
+	Name        string `json:"name" binding:"required,min=1,max=150" example:"ASUS ROG Strix G15"`
+	Description string `json:"description" binding:"omitempty" example:"Gaming laptop with powerful specs"`
+	CategoryID  uint   `json:"category_id" binding:"required,min=1" example:"5"`
+	IsActive    *bool  `json:"is_active" binding:"omitempty" example:"true"`
+}
+
+// UpdateProductRequest represents the request body for updating an existing product
+type UpdateProductRequest struct {
+	Name        *string `json:"name" binding:"omitempty,min=1,max=150" example:"ASUS ROG Strix G15"`
+	Description *string `json:"description" binding:"omitempty" example:"Gaming laptop with powerful specs"`
+	CategoryID  *uint   `json:"category_id" binding:"omitempty,min=1" example:"5"`
+	IsActive    *bool   `json:"is_active" binding:"omitempty" example:"true"`
+	// Keep the current slug when the name changes
+	SlugPinned *bool `json:"slug_pinned" binding:"omitempty" example:"true"`
+}
+
+// GetProductsRequest represents query parameters for listing products
+type GetProductsRequest struct {
+	PaginationRequest
+	Name       string `form:"name" binding:"omitempty,max=150" example:"ROG"`
+	CategoryID *uint  `form:"category_id" binding:"omitempty" example:"5"`
+	IsActive   *bool  `form:"is_active" binding:"omitempty" example:"true"`
+}
@@ -0,0 +1,64 @@
+package request
+
+// CreateSkuRequest represents the request body for creating a new SKU
+type CreateSkuRequest struct {
+	Name        string  `json:"name" binding:"required,min=1,max=200" example:"ASUS ROG Strix G15 - 16GB/512GB"`
+	Description string  `json:"description" binding:"omitempty" example:"Standard configuration"`
+	SkuNumber   string  `json:"sku_number" binding:"required,min=1,max=50" example:"ASUS-ROG-G15-001"`
+	Price       float64 `json:"price" binding:"min=0" example:"15000000"`
+	ProductID   uint    `json:"product_id" binding:"required,min=1" example:"123"`
+	IsActive    *bool   `json:"is_active" binding:"omitempty" example:"true"`
+}
+
+// UpdateSkuRequest represents the request body for updating an existing SKU
+type UpdateSkuRequest struct {
+	Name        *string  `json:"name" binding:"omitempty,min=1,max=200" example:"ASUS ROG Strix G15 - 16GB/512GB"`
+	Description *string  `json:"description" binding:"omitempty" example:"Standard configuration"`
+	SkuNumber   *string  `json:"sku_number" binding:"omitempty,min=1,max=50" example:"ASUS-ROG-G15-001"`
+	Price       *float64 `json:"price" binding:"omitempty,min=0" example:"15000000"`
+	ProductID   *uint    `json:"product_id" binding:"omitempty,min=1" example:"123"`
+	IsActive    *bool    `json:"is_active" binding:"omitempty" example:"true"`
+	// Keep the current slug when the name changes
+	SlugPinned *bool `json:"slug_pinned" binding:"omitempty" example:"true"`
+}
+
+// GetSkusRequest represents query parameters for listing SKUs
+type GetSkusRequest struct {
+	PaginationRequest
+	Name      string `form:"name" binding:"omitempty,max=200" example:"16GB"`
+	SkuNumber string `form:"sku_number" binding:"omitempty,max=50" example:"ASUS-ROG"`
+	ProductID *uint  `form:"product_id" binding:"omitempty" example:"123"`
+	IsActive  *bool  `form:"is_active" binding:"omitempty" example:"true"`
+}
+
+// SkuDisplayRequest represents query parameters that control how attribute values are displayed
+type SkuDisplayRequest struct {
+	// Units lists units to display numbers in; a number is shown in the first unit of its attribute's dimension
+	Units string `form:"units" binding:"omitempty,max=200" example:"TB,MHz"`
+}
+
+// SkuAttributeValueInput represents the value of an attribute in a bulk upsert.
+// A multi-valued attribute takes its ordered list in Values, which replaces the
+// stored list; Value is the same as a list of one.
+type SkuAttributeValueInput struct {
+	AttributeID uint     `json:"attribute_id" binding:"required,min=1" example:"1"`
+	Value       string   `json:"value" binding:"required_without=Values" example:"16"`
+	Values      []string `json:"values" binding:"required_without=Value,omitempty,min=1,max=100,dive,required" example:"iOS,Android"`
+	Sequence    *int     `json:"sequence" binding:"omitempty,min=0" example:"1"`
+}
+
+// List returns the values of the input in order
+func (i *SkuAttributeValueInput) List() []string {
+	if len(i.Values) > 0 {
+		return i.Values
+	}
+	return []string{i.Value}
+}
+
+// UpsertSkuAttributesRequest represents the request body for adding or updating SKU attributes in bulk
+type UpsertSkuAttributesRequest struct {
+	Attributes []SkuAttributeValueInput `json:"attributes" binding:"required,min=1,dive"`
+	// Locale decides how numeric dates such as 03/04/2024 are read; without one,
+	// dates that read differently day-first and month-first are rejected
+	Locale string `json:"locale" binding:"omitempty,max=20" example:"en-US"`
+}
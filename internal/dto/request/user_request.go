@@ -0,0 +1,24 @@
+package request
+
+// CreateUserRequest represents the request body for creating a new user.
+// The SYSTEM role is reserved for the seeded system account.
+type CreateUserRequest struct {
+	Username string `json:"username" binding:"required,min=3,max=50" example:"jdoe"`
+	Password string `json:"password" binding:"required,min=8,max=72" example:"s3cret-pass"`
+	Name     string `json:"name" binding:"required,min=1,max=50" example:"John Doe"`
+	Role     string `json:"role" binding:"required,oneof=ADMIN USER" example:"USER"`
+}
+
+// UpdateUserRequest represents the request body for updating an existing user
+type UpdateUserRequest struct {
+	Password *string `json:"password" binding:"omitempty,min=8,max=72" example:"s3cret-pass"`
+	Name     *string `json:"name" binding:"omitempty,min=1,max=50" example:"John Doe"`
+	Role     *string `json:"role" binding:"omitempty,oneof=ADMIN USER" example:"ADMIN"`
+}
+
+// GetUsersRequest represents query parameters for listing users
+type GetUsersRequest struct {
+	PaginationRequest
+	Username string `form:"username" binding:"omitempty,max=50" example:"jdoe"`
+	Role     string `form:"role" binding:"omitempty,oneof=SYSTEM ADMIN USER" example:"USER"`
+}
@@ -0,0 +1,87 @@
+package response
+
+import "time"
+
+// AttributeResponse represents the basic attribute response
+type AttributeResponse struct {
+	ID          uint                   `json:"id" example:"1"`
+	Name        string                 `json:"name" example:"RAM"`
+	Code        string                 `json:"code" example:"ram"`
+	DataType    string                 `json:"data_type" example:"NUMBER"`
+	UOM         string                 `json:"uom" example:"GB"`
+	Dimension   string                 `json:"dimension,omitempty" example:"storage"` // Dimension of a registered UOM
+	MultiValued bool                   `json:"multi_valued" example:"false"`
+	Rules       AttributeRulesResponse `json:"rules"`
+	IsActive    bool                   `json:"is_active" example:"true"`
+	CreatedAt   time.Time              `json:"created_at" example:"2025-10-17T10:30:00Z"`
+	UpdatedAt   time.Time              `json:"updated_at" example:"2025-10-17T10:30:00Z"`
+	// Options of an OPTION attribute, only present on the attribute detail
+	Options []AttributeOptionResponse `json:"options,omitempty"`
+}
+
+// UnitResponse represents a unit of measurement of the unit catalog
+type UnitResponse struct {
+	Code      string   `json:"code" example:"TB"`
+	Name      string   `json:"name" example:"terabyte"`
+	Dimension string   `json:"dimension" example:"storage"`
+	BaseUnit  string   `json:"base_unit" example:"B"`
+	Factor    string   `json:"factor" example:"1000000000000"` // Base units in one unit
+	Aliases   []string `json:"aliases,omitempty"`
+}
+
+// AttributeRulesResponse represents the validation rules of an attribute; unset rules are omitted
+type AttributeRulesResponse struct {
+	MinValue  *float64 `json:"min_value,omitempty" example:"10"`
+	MaxValue  *float64 `json:"max_value,omitempty" example:"18"`
+	Precision *int     `json:"precision,omitempty" example:"1"`
+	MinLength *int     `json:"min_length,omitempty" example:"1"`
+	MaxLength *int     `json:"max_length,omitempty" example:"50"`
+	Pattern   string   `json:"pattern,omitempty" example:"[A-Z]{2}-[0-9]+"`
+	MinDate   string   `json:"min_date,omitempty" example:"2020-01-01"`
+	MaxDate   string   `json:"max_date,omitempty" example:"2030-12-31"`
+}
+
+// AttributeOptionResponse represents an allowed value of an OPTION attribute
+type AttributeOptionResponse struct {
+	ID          uint      `json:"id" example:"3"`
+	AttributeID uint      `json:"attribute_id" example:"1"`
+	Code        string    `json:"code" example:"red"`
+	Label       string    `json:"label" example:"Red"`
+	Sequence    uint      `json:"sequence" example:"1"`
+	CreatedAt   time.Time `json:"created_at" example:"2025-10-17T10:30:00Z"`
+	UpdatedAt   time.Time `json:"updated_at" example:"2025-10-17T10:30:00Z"`
+}
+
+// AttributeOptionMergeResponse represents the result of merging duplicate options
+type AttributeOptionMergeResponse struct {
+	Option        AttributeOptionResponse `json:"option"`
+	MergedOptions int                     `json:"merged_options" example:"2"`
+	UpdatedValues int64                   `json:"updated_values" example:"35"`
+}
+
+// AttributeUpdateResponse represents the result of an attribute update.
+// DataTypeChange is only present when a data type or rules change was previewed.
+type AttributeUpdateResponse struct {
+	Attribute      AttributeResponse       `json:"attribute"`
+	DryRun         bool                    `json:"dry_run" example:"false"`
+	DataTypeChange *DataTypeChangeResponse `json:"data_type_change,omitempty"`
+}
+
+// DataTypeChangeResponse reports how stored values are affected by a data type change
+type DataTypeChangeResponse struct {
+	AttributeID       uint                        `json:"attribute_id" example:"1"`
+	From              string                      `json:"from" example:"TEXT"`
+	To                string                      `json:"to" example:"NUMBER"`
+	CheckedCount      int64                       `json:"checked_count" example:"250"`
+	IncompatibleCount int64                       `json:"incompatible_count" example:"2"`
+	Incompatible      []IncompatibleValueResponse `json:"incompatible"`
+}
+
+// IncompatibleValueResponse describes a stored value that would not parse under the new data type
+type IncompatibleValueResponse struct {
+	SkuAttributeValueID uint   `json:"sku_attribute_value_id" example:"1001"`
+	SkuID               uint   `json:"sku_id" example:"123"`
+	SkuNumber           string `json:"sku_number" example:"ASUS-ROG-G15-001"`
+	Value               string `json:"value" example:"16GB"`
+	Error               string `json:"error" example:"strconv.ParseFloat: parsing \"16GB\": invalid syntax"`
+}
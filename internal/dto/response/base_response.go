@@ -30,6 +30,14 @@ type PaginationMeta struct {
 	Pages int   `json:"pages" example:"8"`
 }
 
+// CursorMeta contains keyset pagination metadata. A null cursor means there is
+// no page in that direction.
+type CursorMeta struct {
+	Limit      int     `json:"limit" example:"20"`
+	NextCursor *string `json:"next_cursor" example:"eyJzIjoiaWQiLCJ2IjpbIjQyIl19.q0Yl7c3m"`
+	PrevCursor *string `json:"prev_cursor" example:"null"`
+}
+
 // NewSuccessResponse creates a new success response
 func NewSuccessResponse(data interface{}) SuccessResponse {
 	return SuccessResponse{
@@ -77,3 +85,27 @@ func NewPaginationMeta(page, limit int, total int64) PaginationMeta {
 	}
 }
 
+// NewCursorMeta creates keyset pagination metadata; empty cursors are reported as null
+func NewCursorMeta(limit int, nextCursor, prevCursor string) CursorMeta {
+	meta := CursorMeta{Limit: limit}
+	if nextCursor != "" {
+		meta.NextCursor = &nextCursor
+	}
+	if prevCursor != "" {
+		meta.PrevCursor = &prevCursor
+	}
+	return meta
+}
+
+// Error codes used in ErrorDetail.Code
+const (
+	ErrCodeValidation       = "VALIDATION_ERROR"
+	ErrCodeUnauthorized     = "UNAUTHORIZED"
+	ErrCodeForbidden        = "FORBIDDEN"
+	ErrCodeNotFound         = "NOT_FOUND"
+	ErrCodeMoved            = "MOVED"
+	ErrCodeConflict         = "CONFLICT"
+	ErrCodeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+	ErrCodeInternal         = "INTERNAL_ERROR"
+	ErrCodeUnavailable      = "SERVICE_UNAVAILABLE"
+)
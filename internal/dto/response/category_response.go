@@ -7,8 +7,11 @@ type CategoryResponse struct {
 	ID          uint      `json:"id" example:"1"`
 	Name        string    `json:"name" example:"Electronics"`
 	Slug        string    `json:"slug" example:"electronics"`
+	SlugPinned  bool      `json:"slug_pinned" example:"false"`
+	FullPath    string    `json:"full_path" example:"electronics"`
 	Description string    `json:"description" example:"All electronic products"`
 	ParentID    *uint     `json:"parent_id" example:"1"`
+	IsActive    bool      `json:"is_active" example:"true"`
 	CreatedAt   time.Time `json:"created_at" example:"2025-10-17T10:30:00Z"`
 	UpdatedAt   time.Time `json:"updated_at" example:"2025-10-17T10:30:00Z"`
 }
@@ -18,23 +21,30 @@ type CategoryDetailResponse struct {
 	ID          uint              `json:"id" example:"1"`
 	Name        string            `json:"name" example:"Laptops"`
 	Slug        string            `json:"slug" example:"laptops"`
+	SlugPinned  bool              `json:"slug_pinned" example:"false"`
+	FullPath    string            `json:"full_path" example:"electronics/laptops"`
 	Description string            `json:"description" example:"Laptop computers"`
 	ParentID    *uint             `json:"parent_id" example:"1"`
 	Parent      *CategoryResponse `json:"parent,omitempty"`
+	IsActive    bool              `json:"is_active" example:"true"`
 	CreatedAt   time.Time         `json:"created_at" example:"2025-10-17T10:30:00Z"`
 	UpdatedAt   time.Time         `json:"updated_at" example:"2025-10-17T10:30:00Z"`
 }
 
-// CategoryWithChildrenResponse represents a category with its children (hierarchical)
+// CategoryWithChildrenResponse represents a category with its children (hierarchical).
+// ProductCount is only set when requested and includes the products of all descendants.
 type CategoryWithChildrenResponse struct {
-	ID          uint                           `json:"id" example:"1"`
-	Name        string                         `json:"name" example:"Electronics"`
-	Slug        string                         `json:"slug" example:"electronics"`
-	Description string                         `json:"description" example:"All electronic products"`
-	ParentID    *uint                          `json:"parent_id" example:"null"`
-	Children    []CategoryWithChildrenResponse `json:"children,omitempty"`
-	CreatedAt   time.Time                      `json:"created_at" example:"2025-10-17T10:30:00Z"`
-	UpdatedAt   time.Time                      `json:"updated_at" example:"2025-10-17T10:30:00Z"`
+	ID           uint                           `json:"id" example:"1"`
+	Name         string                         `json:"name" example:"Electronics"`
+	Slug         string                         `json:"slug" example:"electronics"`
+	FullPath     string                         `json:"full_path" example:"electronics"`
+	Description  string                         `json:"description" example:"All electronic products"`
+	ParentID     *uint                          `json:"parent_id" example:"null"`
+	IsActive     bool                           `json:"is_active" example:"true"`
+	ProductCount *int64                         `json:"product_count,omitempty" example:"45"`
+	Children     []CategoryWithChildrenResponse `json:"children,omitempty"`
+	CreatedAt    time.Time                      `json:"created_at" example:"2025-10-17T10:30:00Z"`
+	UpdatedAt    time.Time                      `json:"updated_at" example:"2025-10-17T10:30:00Z"`
 }
 
 // CategoryTreeResponse represents the full category tree structure
@@ -56,8 +66,15 @@ type CategoryWithProductCountResponse struct {
 
 // SimpleCategoryResponse represents minimal category info (for nested responses)
 type SimpleCategoryResponse struct {
-	ID   uint   `json:"id" example:"1"`
-	Name string `json:"name" example:"Electronics"`
-	Slug string `json:"slug" example:"electronics"`
+	ID       uint   `json:"id" example:"1"`
+	Name     string `json:"name" example:"Electronics"`
+	Slug     string `json:"slug" example:"electronics"`
+	FullPath string `json:"full_path" example:"electronics"`
 }
 
+// CategoryPathResponse represents a category resolved from its full path, with the
+// breadcrumbs from the root category down to and including the category itself
+type CategoryPathResponse struct {
+	Category    CategoryDetailResponse   `json:"category"`
+	Breadcrumbs []SimpleCategoryResponse `json:"breadcrumbs"`
+}
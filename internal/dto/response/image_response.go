@@ -0,0 +1,12 @@
+package response
+
+import "time"
+
+// ImageResponse represents an image attached to a product or SKU
+type ImageResponse struct {
+	ID        uint      `json:"id" example:"501"`
+	File      string    `json:"file" example:"/uploads/products/product-123-image-501.jpg"`
+	Title     string    `json:"title" example:"Front View"`
+	IsPrimary bool      `json:"is_primary" example:"true"`
+	CreatedAt time.Time `json:"created_at" example:"2025-10-17T10:30:00Z"`
+}
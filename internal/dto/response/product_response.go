@@ -0,0 +1,40 @@
+package response
+
+import "time"
+
+// ProductResponse represents the basic product response
+type ProductResponse struct {
+	ID          uint                    `json:"id" example:"123"`
+	Name        string                  `json:"name" example:"ASUS ROG Strix G15"`
+	Slug        string                  `json:"slug" example:"asus-rog-strix-g15"`
+	SlugPinned  bool                    `json:"slug_pinned" example:"false"`
+	Description string                  `json:"description" example:"Gaming laptop with powerful specs"`
+	CategoryID  uint                    `json:"category_id" example:"5"`
+	Category    *SimpleCategoryResponse `json:"category,omitempty"`
+	IsActive    bool                    `json:"is_active" example:"true"`
+	CreatedAt   time.Time               `json:"created_at" example:"2025-10-17T10:30:00Z"`
+	UpdatedAt   time.Time               `json:"updated_at" example:"2025-10-17T10:30:00Z"`
+}
+
+// ProductDetailResponse represents a product with its category, images and SKUs
+type ProductDetailResponse struct {
+	ID          uint                    `json:"id" example:"123"`
+	Name        string                  `json:"name" example:"ASUS ROG Strix G15"`
+	Slug        string                  `json:"slug" example:"asus-rog-strix-g15"`
+	SlugPinned  bool                    `json:"slug_pinned" example:"false"`
+	Description string                  `json:"description" example:"Gaming laptop with powerful specs"`
+	CategoryID  uint                    `json:"category_id" example:"5"`
+	Category    *SimpleCategoryResponse `json:"category,omitempty"`
+	Images      []ImageResponse         `json:"images"`
+	Skus        []SkuResponse           `json:"skus"`
+	IsActive    bool                    `json:"is_active" example:"true"`
+	CreatedAt   time.Time               `json:"created_at" example:"2025-10-17T10:30:00Z"`
+	UpdatedAt   time.Time               `json:"updated_at" example:"2025-10-17T10:30:00Z"`
+}
+
+// SimpleProductResponse represents minimal product info (for nested responses)
+type SimpleProductResponse struct {
+	ID   uint   `json:"id" example:"123"`
+	Name string `json:"name" example:"ASUS ROG Strix G15"`
+	Slug string `json:"slug" example:"asus-rog-strix-g15"`
+}
@@ -0,0 +1,73 @@
+package response
+
+import "time"
+
+// SkuResponse represents the basic SKU response
+type SkuResponse struct {
+	ID          uint      `json:"id" example:"1001"`
+	Name        string    `json:"name" example:"ASUS ROG Strix G15 - 16GB/512GB"`
+	Slug        string    `json:"slug" example:"asus-rog-strix-g15-16gb-512gb"`
+	SlugPinned  bool      `json:"slug_pinned" example:"false"`
+	Description string    `json:"description" example:"Standard configuration"`
+	SkuNumber   string    `json:"sku_number" example:"ASUS-ROG-G15-001"`
+	Price       float64   `json:"price" example:"15000000"`
+	ProductID   uint      `json:"product_id" example:"123"`
+	IsActive    bool      `json:"is_active" example:"true"`
+	CreatedAt   time.Time `json:"created_at" example:"2025-10-17T10:30:00Z"`
+	UpdatedAt   time.Time `json:"updated_at" example:"2025-10-17T10:30:00Z"`
+}
+
+// SkuDetailResponse represents a SKU with its product, images and attribute values
+type SkuDetailResponse struct {
+	ID          uint                        `json:"id" example:"1001"`
+	Name        string                      `json:"name" example:"ASUS ROG Strix G15 - 16GB/512GB"`
+	Slug        string                      `json:"slug" example:"asus-rog-strix-g15-16gb-512gb"`
+	SlugPinned  bool                        `json:"slug_pinned" example:"false"`
+	Description string                      `json:"description" example:"Standard configuration"`
+	SkuNumber   string                      `json:"sku_number" example:"ASUS-ROG-G15-001"`
+	Price       float64                     `json:"price" example:"15000000"`
+	ProductID   uint                        `json:"product_id" example:"123"`
+	Product     *SimpleProductResponse      `json:"product,omitempty"`
+	Images      []ImageResponse             `json:"images"`
+	Attributes  []SkuAttributeValueResponse `json:"attributes"`
+	IsActive    bool                        `json:"is_active" example:"true"`
+	CreatedAt   time.Time                   `json:"created_at" example:"2025-10-17T10:30:00Z"`
+	UpdatedAt   time.Time                   `json:"updated_at" example:"2025-10-17T10:30:00Z"`
+}
+
+// SkuAttributeValueResponse represents an attribute value joined with its attribute
+type SkuAttributeValueResponse struct {
+	ID            uint   `json:"id" example:"1001"`
+	AttributeID   uint   `json:"attribute_id" example:"1"`
+	AttributeName string `json:"attribute_name" example:"RAM"`
+	AttributeCode string `json:"attribute_code" example:"ram"`
+	Value         string `json:"value" example:"16"`
+	Label         string `json:"label,omitempty" example:"Space Black"` // Option label of an OPTION attribute
+	UOM           string `json:"uom,omitempty" example:"GB"`
+	// DisplayValue is the value as shown to users, e.g. in the unit asked for with ?units=
+	DisplayValue string `json:"display_value,omitempty" example:"16 GB"`
+	// BaseValue is a number in the base unit of its dimension, e.g. bytes for GB
+	BaseValue *float64 `json:"base_value,omitempty" example:"16000000000"`
+	Position  int      `json:"position" example:"0"` // Place in the value list of a multi-valued attribute
+	// PossiblyRounded flags an old number that may have been rounded to two decimals and needs review
+	PossiblyRounded bool `json:"possibly_rounded,omitempty" example:"false"`
+	Sequence        int  `json:"sequence" example:"1"`
+}
+
+// SkuAttributesResponse represents all attribute values of a SKU
+type SkuAttributesResponse struct {
+	SkuID      uint                        `json:"sku_id" example:"123"`
+	Attributes []SkuAttributeValueResponse `json:"attributes"`
+}
+
+// AttributeValueError describes why a single attribute value in a bulk request was rejected
+type AttributeValueError struct {
+	Index       int      `json:"index" example:"0"`
+	AttributeID uint     `json:"attribute_id" example:"1"`
+	Value       string   `json:"value" example:"sixteen"`
+	Values      []string `json:"values,omitempty"`
+	Message     string   `json:"message" example:"invalid value for attribute 'RAM' (type: NUMBER)"`
+	// Rule and Limit describe the broken rule of a value that parsed but is out of bounds
+	Rule  string      `json:"rule,omitempty" example:"min_value"`
+	Limit interface{} `json:"limit,omitempty"`
+}
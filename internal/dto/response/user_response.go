@@ -0,0 +1,13 @@
+package response
+
+import "time"
+
+// UserResponse represents a user account without its credentials
+type UserResponse struct {
+	ID        uint      `json:"id" example:"2"`
+	Username  string    `json:"username" example:"jdoe"`
+	Name      string    `json:"name" example:"John Doe"`
+	Role      string    `json:"role" example:"USER"`
+	CreatedAt time.Time `json:"created_at" example:"2025-10-17T10:30:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2025-10-17T10:30:00Z"`
+}
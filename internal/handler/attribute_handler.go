@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/mapper"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/response"
+	"github.com/Wilson1510/klampis-pim-go/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// AttributeHandler serves the /attributes resource
+type AttributeHandler struct {
+	service *service.AttributeService
+}
+
+// NewAttributeHandler creates a new AttributeHandler
+func NewAttributeHandler(service *service.AttributeService) *AttributeHandler {
+	return &AttributeHandler{service: service}
+}
+
+// List handles GET /attributes
+func (h *AttributeHandler) List(c *gin.Context) {
+	var req request.GetAttributesRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+
+	attributes, page, err := h.service.List(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponseWithMeta(
+		mapper.ToAttributeResponseList(attributes),
+		listMeta(&req.PaginationRequest, page),
+	))
+}
+
+// Get handles GET /attributes/:id
+func (h *AttributeHandler) Get(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	attribute, err := h.service.Get(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(mapper.ToAttributeResponse(attribute)))
+}
+
+// Create handles POST /attributes
+func (h *AttributeHandler) Create(c *gin.Context) {
+	var req request.CreateAttributeRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	attribute, err := h.service.Create(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse(mapper.ToAttributeResponse(attribute)))
+}
+
+// Update handles PUT /attributes/:id, with ?dry_run=true previewing a data type change
+func (h *AttributeHandler) Update(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var options request.UpdateAttributeOptions
+	if !bindQuery(c, &options) {
+		return
+	}
+
+	var req request.UpdateAttributeRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	attribute, report, err := h.service.Update(c.Request.Context(), id, &req, options.DryRun)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	resp := response.AttributeUpdateResponse{
+		Attribute: mapper.ToAttributeResponse(attribute),
+		DryRun:    options.DryRun,
+	}
+	if report != nil {
+		changeResp := mapper.ToDataTypeChangeResponse(report)
+		resp.DataTypeChange = &changeResp
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(resp))
+}
+
+// Delete handles DELETE /attributes/:id
+func (h *AttributeHandler) Delete(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(nil))
+}
+
+// Units handles GET /uoms
+func (h *AttributeHandler) Units(c *gin.Context) {
+	c.JSON(http.StatusOK, response.NewSuccessResponse(mapper.ToUnitResponseList(h.service.Units())))
+}
+
+// ListOptions handles GET /attributes/:id/options
+func (h *AttributeHandler) ListOptions(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	options, err := h.service.ListOptions(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(mapper.ToAttributeOptionResponseList(options)))
+}
+
+// CreateOption handles POST /attributes/:id/options
+func (h *AttributeHandler) CreateOption(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req request.CreateAttributeOptionRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	option, err := h.service.CreateOption(c.Request.Context(), id, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse(mapper.ToAttributeOptionResponse(option)))
+}
+
+// UpdateOption handles PUT /attributes/:id/options/:option_id
+func (h *AttributeHandler) UpdateOption(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	optionID, ok := parseIDParam(c, "option_id")
+	if !ok {
+		return
+	}
+
+	var req request.UpdateAttributeOptionRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	option, err := h.service.UpdateOption(c.Request.Context(), id, optionID, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(mapper.ToAttributeOptionResponse(option)))
+}
+
+// DeleteOption handles DELETE /attributes/:id/options/:option_id
+func (h *AttributeHandler) DeleteOption(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	optionID, ok := parseIDParam(c, "option_id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteOption(c.Request.Context(), id, optionID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(nil))
+}
+
+// MergeOptions handles POST /attributes/:id/options/merge
+func (h *AttributeHandler) MergeOptions(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req request.MergeAttributeOptionsRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	result, err := h.service.MergeOptions(c.Request.Context(), id, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(response.AttributeOptionMergeResponse{
+		Option:        mapper.ToAttributeOptionResponse(&result.Option),
+		MergedOptions: result.MergedOptions,
+		UpdatedValues: result.UpdatedValues,
+	}))
+}
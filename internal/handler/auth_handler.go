@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/auth"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/response"
+	"github.com/Wilson1510/klampis-pim-go/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// AuthHandler serves the /auth endpoints
+type AuthHandler struct {
+	service *service.AuthService
+}
+
+// NewAuthHandler creates a new AuthHandler
+func NewAuthHandler(service *service.AuthService) *AuthHandler {
+	return &AuthHandler{service: service}
+}
+
+// Login handles POST /auth/login
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req request.LoginRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	pair, err := h.service.Login(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(toTokenResponse(pair)))
+}
+
+// Refresh handles POST /auth/refresh
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req request.RefreshTokenRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	pair, err := h.service.Refresh(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(toTokenResponse(pair)))
+}
+
+// Logout handles POST /auth/logout
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req request.LogoutRequest
+	if c.Request.ContentLength != 0 && !bindJSON(c, &req) {
+		return
+	}
+
+	claims, ok := auth.ClaimsFromContext(c.Request.Context())
+	if !ok {
+		respondError(c, service.ErrUnauthenticated)
+		return
+	}
+
+	if err := h.service.Logout(c.Request.Context(), claims, &req); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(nil))
+}
+
+// toTokenResponse converts a TokenPair to TokenResponse DTO
+func toTokenResponse(pair *service.TokenPair) response.TokenResponse {
+	return response.TokenResponse{
+		AccessToken:  pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(pair.ExpiresIn.Seconds()),
+	}
+}
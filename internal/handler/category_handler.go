@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/mapper"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/response"
+	"github.com/Wilson1510/klampis-pim-go/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// CategoryHandler serves the /categories resource
+type CategoryHandler struct {
+	service *service.CategoryService
+}
+
+// NewCategoryHandler creates a new CategoryHandler
+func NewCategoryHandler(service *service.CategoryService) *CategoryHandler {
+	return &CategoryHandler{service: service}
+}
+
+// List handles GET /categories
+func (h *CategoryHandler) List(c *gin.Context) {
+	var req request.GetCategoriesRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+
+	categories, page, err := h.service.List(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponseWithMeta(
+		mapper.ToCategoryResponseList(categories),
+		listMeta(&req.PaginationRequest, page),
+	))
+}
+
+// Tree handles GET /categories/tree
+func (h *CategoryHandler) Tree(c *gin.Context) {
+	var req request.GetCategoryTreeRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+
+	roots, counts, err := h.service.Tree(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	tree := mapper.ToCategoryTreeResponse(roots)
+	if req.IncludeProductCount {
+		tree = mapper.ToCategoryTreeResponseWithCounts(roots, counts)
+	}
+	c.JSON(http.StatusOK, response.NewSuccessResponse(tree))
+}
+
+// Get handles GET /categories/:id
+func (h *CategoryHandler) Get(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	category, err := h.service.Get(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(mapper.ToCategoryDetailResponse(category)))
+}
+
+// GetByPath handles GET /catalog/categories/path/*path
+func (h *CategoryHandler) GetByPath(c *gin.Context) {
+	category, ancestors, err := h.service.GetByPath(c.Request.Context(), c.Param("path"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(mapper.ToCategoryPathResponse(category, ancestors)))
+}
+
+// Create handles POST /categories
+func (h *CategoryHandler) Create(c *gin.Context) {
+	var req request.CreateCategoryRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	category, err := h.service.Create(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse(mapper.ToCategoryDetailResponse(category)))
+}
+
+// Update handles PUT /categories/:id
+func (h *CategoryHandler) Update(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req request.UpdateCategoryRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	category, err := h.service.Update(c.Request.Context(), id, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(mapper.ToCategoryDetailResponse(category)))
+}
+
+// Move handles POST /categories/:id/move
+func (h *CategoryHandler) Move(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req request.MoveCategoryRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	category, err := h.service.Move(c.Request.Context(), id, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(mapper.ToCategoryDetailResponse(category)))
+}
+
+// Delete handles DELETE /categories/:id
+func (h *CategoryHandler) Delete(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req request.DeleteCategoryRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), id, &req); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(nil))
+}
+
+// Children handles GET /categories/:id/children
+func (h *CategoryHandler) Children(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var pagination request.PaginationRequest
+	if !bindQuery(c, &pagination) {
+		return
+	}
+
+	children, page, err := h.service.Children(c.Request.Context(), id, &pagination)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponseWithMeta(
+		mapper.ToCategoryResponseList(children),
+		listMeta(&pagination, page),
+	))
+}
+
+// Products handles GET /categories/:id/products
+func (h *CategoryHandler) Products(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req request.GetCategoryProductsRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+
+	products, page, err := h.service.Products(c.Request.Context(), id, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponseWithMeta(
+		mapper.ToProductResponseList(products),
+		listMeta(&req.PaginationRequest, page),
+	))
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/response"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// HealthHandler reports whether the API and its database are reachable
+type HealthHandler struct {
+	db *gorm.DB
+}
+
+// NewHealthHandler creates a new HealthHandler
+func NewHealthHandler(db *gorm.DB) *HealthHandler {
+	return &HealthHandler{db: db}
+}
+
+// Check handles GET /health
+func (h *HealthHandler) Check(c *gin.Context) {
+	sqlDB, err := h.db.DB()
+	if err == nil {
+		err = sqlDB.PingContext(c.Request.Context())
+	}
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, response.NewErrorResponse(
+			response.ErrCodeUnavailable, "Database is unreachable", nil))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(gin.H{"status": "ok"}))
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/mapper"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/response"
+	"github.com/Wilson1510/klampis-pim-go/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// ProductHandler serves the /products resource
+type ProductHandler struct {
+	service *service.ProductService
+}
+
+// NewProductHandler creates a new ProductHandler
+func NewProductHandler(service *service.ProductService) *ProductHandler {
+	return &ProductHandler{service: service}
+}
+
+// List handles GET /products
+func (h *ProductHandler) List(c *gin.Context) {
+	var req request.GetProductsRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+
+	products, page, err := h.service.List(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponseWithMeta(
+		mapper.ToProductResponseList(products),
+		listMeta(&req.PaginationRequest, page),
+	))
+}
+
+// Get handles GET /products/:id
+func (h *ProductHandler) Get(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	product, err := h.service.Get(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(mapper.ToProductDetailResponse(product)))
+}
+
+// GetBySlug handles GET /catalog/products/:slug
+func (h *ProductHandler) GetBySlug(c *gin.Context) {
+	product, err := h.service.GetBySlug(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(mapper.ToProductDetailResponse(product)))
+}
+
+// Create handles POST /products
+func (h *ProductHandler) Create(c *gin.Context) {
+	var req request.CreateProductRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	product, err := h.service.Create(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse(mapper.ToProductDetailResponse(product)))
+}
+
+// Update handles PUT /products/:id
+func (h *ProductHandler) Update(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req request.UpdateProductRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	product, err := h.service.Update(c.Request.Context(), id, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(mapper.ToProductDetailResponse(product)))
+}
+
+// Delete handles DELETE /products/:id
+func (h *ProductHandler) Delete(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(nil))
+}
+
+// Skus handles GET /products/:id/skus
+func (h *ProductHandler) Skus(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var pagination request.PaginationRequest
+	if !bindQuery(c, &pagination) {
+		return
+	}
+
+	skus, page, err := h.service.Skus(c.Request.Context(), id, &pagination)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponseWithMeta(
+		mapper.ToSkuResponseList(skus),
+		listMeta(&pagination, page),
+	))
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/response"
+	"github.com/Wilson1510/klampis-pim-go/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// respondError writes the ErrorResponse matching a service error
+func respondError(c *gin.Context, err error) {
+	var validationErr *service.ValidationError
+	var conflictErr *service.ConflictError
+	var movedErr *service.MovedError
+
+	switch {
+	case errors.Is(err, service.ErrInvalidCredentials):
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse(
+			response.ErrCodeUnauthorized, "Invalid username or password", nil))
+	case errors.Is(err, service.ErrUnauthenticated):
+		c.JSON(http.StatusUnauthorized, response.NewErrorResponse(
+			response.ErrCodeUnauthorized, "Authentication required", nil))
+	case errors.Is(err, service.ErrNotFound):
+		c.JSON(http.StatusNotFound, response.NewErrorResponse(
+			response.ErrCodeNotFound, "Resource not found", nil))
+	case errors.As(err, &movedErr):
+		c.Header("Location", movedLocation(c, movedErr.MovedTo))
+		c.JSON(http.StatusMovedPermanently, response.NewErrorResponse(
+			response.ErrCodeMoved, "Resource has moved", map[string]string{"moved_to": movedErr.MovedTo}))
+	case errors.As(err, &validationErr):
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+			response.ErrCodeValidation, validationErr.Message, validationErr.Details))
+	case errors.As(err, &conflictErr):
+		c.JSON(http.StatusConflict, response.NewErrorResponse(
+			response.ErrCodeConflict, conflictErr.Message, conflictErr.Details))
+	default:
+		log.Printf("Internal error on %s %s: %v", c.Request.Method, c.FullPath(), err)
+		c.JSON(http.StatusInternalServerError, response.NewErrorResponse(
+			response.ErrCodeInternal, "Internal server error", nil))
+	}
+}
+
+// movedLocation builds the URL of the current resource by replacing the value of
+// the route's last parameter with movedTo, keeping the query string
+func movedLocation(c *gin.Context, movedTo string) string {
+	route := c.FullPath()
+	i := strings.LastIndexAny(route, ":*")
+	if i < 0 {
+		return ""
+	}
+
+	location := strings.TrimSuffix(route[:i], "/") + "/" + movedTo
+	if c.Request.URL.RawQuery != "" {
+		location += "?" + c.Request.URL.RawQuery
+	}
+	return location
+}
+
+// listMeta returns the meta block of a list response: cursors for keyset
+// pagination, page counts otherwise
+func listMeta(pagination *request.PaginationRequest, page *service.PageInfo) interface{} {
+	if pagination.IsCursorMode() {
+		return response.NewCursorMeta(pagination.GetLimit(), page.NextCursor, page.PrevCursor)
+	}
+	return response.NewPaginationMeta(pagination.GetPage(), pagination.GetLimit(), page.Total)
+}
+
+// respondBindingError writes a VALIDATION_ERROR response for a failed request binding
+func respondBindingError(c *gin.Context, err error) {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+			response.ErrCodeValidation, "Invalid input data", err.Error()))
+		return
+	}
+
+	details := make(map[string]string, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		details[toSnakeCase(fieldErr.Field())] = validationMessage(fieldErr)
+	}
+
+	c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+		response.ErrCodeValidation, "Invalid input data", details))
+}
+
+// bindJSON binds the request body and responds with a validation error on failure
+func bindJSON(c *gin.Context, obj interface{}) bool {
+	if err := c.ShouldBindJSON(obj); err != nil {
+		respondBindingError(c, err)
+		return false
+	}
+	return true
+}
+
+// filterable is implemented by list requests that accept ?filter[...] parameters
+type filterable interface {
+	SetFilters(filters []request.Filter)
+}
+
+// bindQuery binds the query string and responds with a validation error on failure.
+// List requests also receive the parsed ?filter[field][op]=value parameters.
+func bindQuery(c *gin.Context, obj interface{}) bool {
+	if err := c.ShouldBindQuery(obj); err != nil {
+		respondBindingError(c, err)
+		return false
+	}
+
+	if target, ok := obj.(filterable); ok {
+		filters, err := request.ParseFilters(c.Request.URL.Query())
+		var parseErr *request.FilterParseError
+		if errors.As(err, &parseErr) {
+			c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+				response.ErrCodeValidation, "Invalid filter parameters", parseErr.Details))
+			return false
+		}
+		target.SetFilters(filters)
+	}
+	return true
+}
+
+// parseIDParam reads a positive numeric path parameter and responds with a validation error on failure
+func parseIDParam(c *gin.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, response.NewErrorResponse(
+			response.ErrCodeValidation, "Invalid input data",
+			map[string]string{name: "must be a positive integer"}))
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// validationMessage describes a single failed validation rule
+func validationMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		return "must be at least " + fieldErr.Param()
+	case "max":
+		return "must be at most " + fieldErr.Param()
+	case "oneof":
+		return "must be one of: " + fieldErr.Param()
+	default:
+		return "failed on the '" + fieldErr.Tag() + "' rule"
+	}
+}
+
+// toSnakeCase converts a Go field name such as ParentID to parent_id
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			prevLower := i > 0 && !unicode.IsUpper(runes[i-1])
+			nextLower := i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || nextLower {
+				b.WriteByte('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
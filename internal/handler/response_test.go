@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/response"
+	"github.com/Wilson1510/klampis-pim-go/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func TestRespondError(t *testing.T) {
+	testCases := []struct {
+		name         string
+		err          error
+		expectedCode int
+		expectedErr  string
+	}{
+		{
+			name:         "Not found",
+			err:          fmt.Errorf("loading category: %w", service.ErrNotFound),
+			expectedCode: http.StatusNotFound,
+			expectedErr:  response.ErrCodeNotFound,
+		},
+		{
+			name:         "Validation error",
+			err:          service.NewValidationError(nil, "bad input"),
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  response.ErrCodeValidation,
+		},
+		{
+			name:         "Conflict error",
+			err:          service.NewConflictError(nil, "still in use"),
+			expectedCode: http.StatusConflict,
+			expectedErr:  response.ErrCodeConflict,
+		},
+		{
+			name:         "Unexpected error",
+			err:          fmt.Errorf("connection reset"),
+			expectedCode: http.StatusInternalServerError,
+			expectedErr:  response.ErrCodeInternal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+			respondError(c, tc.err)
+
+			var body response.ErrorResponse
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, tc.expectedCode, w.Code)
+			assert.False(t, body.Success)
+			assert.Equal(t, tc.expectedErr, body.Error.Code)
+		})
+	}
+}
+
+func TestRespondErrorMoved(t *testing.T) {
+	testCases := []struct {
+		name             string
+		route            string
+		target           string
+		movedTo          string
+		expectedLocation string
+	}{
+		{
+			name:             "Slug parameter",
+			route:            "/catalog/products/:slug",
+			target:           "/catalog/products/old-name?lang=en",
+			movedTo:          "new-name",
+			expectedLocation: "/catalog/products/new-name?lang=en",
+		},
+		{
+			name:             "Catch-all path parameter",
+			route:            "/catalog/categories/path/*path",
+			target:           "/catalog/categories/path/electronics/old",
+			movedTo:          "electronics/laptops",
+			expectedLocation: "/catalog/categories/path/electronics/laptops",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := gin.New()
+			r.GET(tc.route, func(c *gin.Context) {
+				respondError(c, &service.MovedError{MovedTo: tc.movedTo})
+			})
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.target, nil))
+
+			var body struct {
+				Error struct {
+					Code    string            `json:"code"`
+					Details map[string]string `json:"details"`
+				} `json:"error"`
+			}
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, http.StatusMovedPermanently, w.Code)
+			assert.Equal(t, tc.expectedLocation, w.Header().Get("Location"))
+			assert.Equal(t, response.ErrCodeMoved, body.Error.Code)
+			assert.Equal(t, tc.movedTo, body.Error.Details["moved_to"])
+		})
+	}
+}
+
+func TestParseIDParam(t *testing.T) {
+	testCases := []struct {
+		name       string
+		value      string
+		expectedOK bool
+		expectedID uint
+	}{
+		{name: "Valid ID", value: "42", expectedOK: true, expectedID: 42},
+		{name: "Zero ID", value: "0", expectedOK: false},
+		{name: "Negative ID", value: "-1", expectedOK: false},
+		{name: "Non-numeric ID", value: "abc", expectedOK: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: tc.value}}
+
+			id, ok := parseIDParam(c, "id")
+
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedID, id)
+			if !ok {
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+			}
+		})
+	}
+}
+
+func TestBindJSONValidationDetails(t *testing.T) {
+	type payload struct {
+		Name     string `json:"name" binding:"required"`
+		ParentID uint   `json:"parent_id" binding:"omitempty,max=10"`
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"parent_id": 11}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	var req payload
+	ok := bindJSON(c, &req)
+
+	var body struct {
+		Error struct {
+			Code    string            `json:"code"`
+			Details map[string]string `json:"details"`
+		} `json:"error"`
+	}
+	assert.False(t, ok)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, response.ErrCodeValidation, body.Error.Code)
+	assert.Equal(t, "is required", body.Error.Details["name"])
+	assert.Equal(t, "must be at most 10", body.Error.Details["parent_id"])
+}
+
+func TestBindQueryFilters(t *testing.T) {
+	t.Run("Filters are parsed into list requests", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/?page=2&filter[price][gt]=100", nil)
+
+		var req request.GetSkusRequest
+		ok := bindQuery(c, &req)
+
+		assert.True(t, ok)
+		assert.Equal(t, 2, req.Page)
+		assert.Equal(t, []request.Filter{{Field: "price", Operator: request.FilterGt, Value: "100"}}, req.Filters)
+	})
+
+	t.Run("Unknown operator is a validation error", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/?filter[price][between]=1", nil)
+
+		var req request.GetSkusRequest
+		ok := bindQuery(c, &req)
+
+		var body struct {
+			Error struct {
+				Code    string            `json:"code"`
+				Details map[string]string `json:"details"`
+			} `json:"error"`
+		}
+		assert.False(t, ok)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, response.ErrCodeValidation, body.Error.Code)
+		assert.Contains(t, body.Error.Details, "filter[price][between]")
+	})
+}
+
+func TestToSnakeCase(t *testing.T) {
+	assert.Equal(t, "name", toSnakeCase("Name"))
+	assert.Equal(t, "parent_id", toSnakeCase("ParentID"))
+	assert.Equal(t, "sku_number", toSnakeCase("SkuNumber"))
+	assert.Equal(t, "uom", toSnakeCase("UOM"))
+}
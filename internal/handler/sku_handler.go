@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/mapper"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/response"
+	"github.com/Wilson1510/klampis-pim-go/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// SkuHandler serves the /skus resource
+type SkuHandler struct {
+	service *service.SkuService
+}
+
+// NewSkuHandler creates a new SkuHandler
+func NewSkuHandler(service *service.SkuService) *SkuHandler {
+	return &SkuHandler{service: service}
+}
+
+// List handles GET /skus
+func (h *SkuHandler) List(c *gin.Context) {
+	var req request.GetSkusRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+
+	skus, page, err := h.service.List(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponseWithMeta(
+		mapper.ToSkuResponseList(skus),
+		listMeta(&req.PaginationRequest, page),
+	))
+}
+
+// Get handles GET /skus/:id, with ?units= choosing the units numbers are displayed in
+func (h *SkuHandler) Get(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var display request.SkuDisplayRequest
+	if !bindQuery(c, &display) {
+		return
+	}
+
+	sku, err := h.service.Get(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if err := h.service.SetDisplayUnits(sku.AttributeValues, display.Units); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(mapper.ToSkuDetailResponse(sku)))
+}
+
+// GetBySlug handles GET /catalog/skus/:slug, with ?units= like Get
+func (h *SkuHandler) GetBySlug(c *gin.Context) {
+	var display request.SkuDisplayRequest
+	if !bindQuery(c, &display) {
+		return
+	}
+
+	sku, err := h.service.GetBySlug(c.Request.Context(), c.Param("slug"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if err := h.service.SetDisplayUnits(sku.AttributeValues, display.Units); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(mapper.ToSkuDetailResponse(sku)))
+}
+
+// Create handles POST /skus
+func (h *SkuHandler) Create(c *gin.Context) {
+	var req request.CreateSkuRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	sku, err := h.service.Create(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse(mapper.ToSkuDetailResponse(sku)))
+}
+
+// Update handles PUT /skus/:id
+func (h *SkuHandler) Update(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req request.UpdateSkuRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	sku, err := h.service.Update(c.Request.Context(), id, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(mapper.ToSkuDetailResponse(sku)))
+}
+
+// Delete handles DELETE /skus/:id
+func (h *SkuHandler) Delete(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(nil))
+}
+
+// Attributes handles GET /skus/:id/attributes, with ?units= like Get
+func (h *SkuHandler) Attributes(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var display request.SkuDisplayRequest
+	if !bindQuery(c, &display) {
+		return
+	}
+
+	values, err := h.service.Attributes(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if err := h.service.SetDisplayUnits(values, display.Units); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(mapper.ToSkuAttributesResponse(id, values)))
+}
+
+// UpsertAttributes handles POST /skus/:id/attributes
+func (h *SkuHandler) UpsertAttributes(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req request.UpsertSkuAttributesRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	values, err := h.service.UpsertAttributes(c.Request.Context(), id, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(mapper.ToSkuAttributesResponse(id, values)))
+}
+
+// RemoveAttribute handles DELETE /skus/:id/attributes/:attribute_id
+func (h *SkuHandler) RemoveAttribute(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	attributeID, ok := parseIDParam(c, "attribute_id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.RemoveAttribute(c.Request.Context(), id, attributeID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(nil))
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/mapper"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/response"
+	"github.com/Wilson1510/klampis-pim-go/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// UserHandler serves the /users resource
+type UserHandler struct {
+	service *service.UserService
+}
+
+// NewUserHandler creates a new UserHandler
+func NewUserHandler(service *service.UserService) *UserHandler {
+	return &UserHandler{service: service}
+}
+
+// List handles GET /users
+func (h *UserHandler) List(c *gin.Context) {
+	var req request.GetUsersRequest
+	if !bindQuery(c, &req) {
+		return
+	}
+
+	users, page, err := h.service.List(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponseWithMeta(
+		mapper.ToUserResponseList(users),
+		listMeta(&req.PaginationRequest, page),
+	))
+}
+
+// Get handles GET /users/:id
+func (h *UserHandler) Get(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	user, err := h.service.Get(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(mapper.ToUserResponse(user)))
+}
+
+// Create handles POST /users
+func (h *UserHandler) Create(c *gin.Context) {
+	var req request.CreateUserRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	user, err := h.service.Create(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewSuccessResponse(mapper.ToUserResponse(user)))
+}
+
+// Update handles PUT /users/:id
+func (h *UserHandler) Update(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	var req request.UpdateUserRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	user, err := h.service.Update(c.Request.Context(), id, &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(mapper.ToUserResponse(user)))
+}
+
+// Delete handles DELETE /users/:id
+func (h *UserHandler) Delete(c *gin.Context) {
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewSuccessResponse(nil))
+}
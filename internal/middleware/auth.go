@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/auth"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/response"
+	"github.com/Wilson1510/klampis-pim-go/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// Auth rejects requests without a valid, unrevoked bearer access token.
+// The authenticated user and token claims are stored in the request context
+// and can be read back with auth.UserFromContext and auth.ClaimsFromContext.
+func Auth(authService *service.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			abortUnauthorized(c, "Missing or malformed Authorization header")
+			return
+		}
+
+		user, claims, err := authService.Authenticate(c.Request.Context(), token)
+		if errors.Is(err, service.ErrUnauthenticated) {
+			abortUnauthorized(c, "Invalid, expired or revoked token")
+			return
+		}
+		if err != nil {
+			log.Printf("Failed to authenticate request: %v", err)
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response.NewErrorResponse(
+				response.ErrCodeInternal, "Internal server error", nil))
+			return
+		}
+
+		c.Request = c.Request.WithContext(auth.WithUser(c.Request.Context(), user, claims))
+		c.Next()
+	}
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>" header
+func bearerToken(header string) (string, bool) {
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// abortUnauthorized stops the chain with a 401 ErrorResponse
+func abortUnauthorized(c *gin.Context, message string) {
+	c.Header("WWW-Authenticate", `Bearer realm="api"`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, response.NewErrorResponse(
+		response.ErrCodeUnauthorized, message, nil))
+}
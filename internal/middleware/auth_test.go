@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBearerToken(t *testing.T) {
+	testCases := []struct {
+		name          string
+		header        string
+		expectedToken string
+		expectedOK    bool
+	}{
+		{name: "Valid bearer header", header: "Bearer abc.def.ghi", expectedToken: "abc.def.ghi", expectedOK: true},
+		{name: "Lowercase scheme", header: "bearer abc", expectedToken: "abc", expectedOK: true},
+		{name: "Empty header", header: "", expectedOK: false},
+		{name: "Missing token", header: "Bearer ", expectedOK: false},
+		{name: "Basic scheme", header: "Basic dXNlcjpwYXNz", expectedOK: false},
+		{name: "Token without scheme", header: "abc.def.ghi", expectedOK: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			token, ok := bearerToken(tc.header)
+
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedToken, token)
+		})
+	}
+}
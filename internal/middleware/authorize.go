@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/auth"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/response"
+	"github.com/gin-gonic/gin"
+)
+
+// Authorize rejects requests whose authenticated user's role may not perform
+// action on resource according to the permission matrix. It must run after Auth.
+func Authorize(resource auth.Resource, action auth.Action) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := auth.UserFromContext(c.Request.Context())
+		if !ok {
+			abortUnauthorized(c, "Authentication required")
+			return
+		}
+
+		if !auth.Can(user.Role, resource, action) {
+			c.AbortWithStatusJSON(http.StatusForbidden, response.NewErrorResponse(
+				response.ErrCodeForbidden,
+				fmt.Sprintf("Role %s is not allowed to %s %s", user.Role, action, resource),
+				map[string]string{
+					"role":     string(user.Role),
+					"resource": string(resource),
+					"action":   string(action),
+				},
+			))
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/auth"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/response"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	testCases := []struct {
+		name           string
+		user           *models.User
+		action         auth.Action
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "Admin may delete", user: &models.User{Role: models.RoleAdmin}, action: auth.ActionDelete, expectedStatus: http.StatusOK},
+		{name: "User may update", user: &models.User{Role: models.RoleUser}, action: auth.ActionUpdate, expectedStatus: http.StatusOK},
+		{name: "User may not delete", user: &models.User{Role: models.RoleUser}, action: auth.ActionDelete, expectedStatus: http.StatusForbidden, expectedCode: response.ErrCodeForbidden},
+		{name: "Missing user", user: nil, action: auth.ActionRead, expectedStatus: http.StatusUnauthorized, expectedCode: response.ErrCodeUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				if tc.user != nil {
+					c.Request = c.Request.WithContext(auth.WithUser(c.Request.Context(), tc.user, nil))
+				}
+			})
+			r.GET("/products", Authorize(auth.ResourceProducts, tc.action), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/products", nil))
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+			if tc.expectedCode != "" {
+				var body response.ErrorResponse
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+				assert.Equal(t, tc.expectedCode, body.Error.Code)
+			}
+		})
+	}
+}
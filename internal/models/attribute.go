@@ -3,9 +3,12 @@ package models
 import (
 	"encoding/json"
 	"fmt"
+	"reflect"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/Wilson1510/klampis-pim-go/pkg/utils"
 	"gorm.io/gorm"
 )
 
@@ -17,6 +20,7 @@ const (
 	DataTypeNumber  DataType = "NUMBER"
 	DataTypeBoolean DataType = "BOOLEAN"
 	DataTypeDate    DataType = "DATE"
+	DataTypeOption  DataType = "OPTION" // Value is the code of one of the attribute's options
 )
 
 type Attribute struct {
@@ -24,15 +28,20 @@ type Attribute struct {
 	Name     string   `gorm:"not null;type:varchar(50)" json:"name"`
 	Code     string   `gorm:"uniqueIndex;not null;type:varchar(70)" json:"code"`
 	DataType DataType `gorm:"not null;type:varchar(20)" json:"data_type"`
-	UOM      string   `gorm:"type:varchar(15)" json:"uom"` // Unit of measurement: GB, inch, GHz, years, etc.
+	UOM      string   `gorm:"type:varchar(15)" json:"uom"` // Unit of measurement: GB, in, GHz, yr, etc. (see Units)
+	// MultiValued attributes hold an ordered list of values per SKU, e.g. "Compatible with: iOS, Android"
+	MultiValued bool `gorm:"not null;default:false" json:"multi_valued"`
+	// Rules constrain the values beyond their data type, e.g. a minimum screen size
+	Rules AttributeRules `gorm:"embedded" json:"rules"`
 
 	// Relationships
 	SkuAttributeValues []SkuAttributeValue `gorm:"foreignKey:AttributeID" json:"sku_attribute_values,omitempty"`
+	Options            []AttributeOption   `gorm:"foreignKey:AttributeID" json:"options,omitempty"`
 }
 
 // ValidateDataType validates if the data type is valid
 func (a *Attribute) ValidateDataType() error {
-	validTypes := []DataType{DataTypeText, DataTypeNumber, DataTypeBoolean, DataTypeDate}
+	validTypes := []DataType{DataTypeText, DataTypeNumber, DataTypeBoolean, DataTypeDate, DataTypeOption}
 
 	for _, validType := range validTypes {
 		if a.DataType == validType {
@@ -45,12 +54,199 @@ func (a *Attribute) ValidateDataType() error {
 
 // BeforeCreate GORM hook
 func (a *Attribute) BeforeCreate(tx *gorm.DB) error {
-	return a.ValidateDataType()
+	if err := a.ValidateDataType(); err != nil {
+		return err
+	}
+	return a.Rules.Validate(a.DataType)
 }
 
 // BeforeUpdate GORM hook
 func (a *Attribute) BeforeUpdate(tx *gorm.DB) error {
-	return a.ValidateDataType()
+	if err := a.ValidateDataType(); err != nil {
+		return err
+	}
+	if err := a.Rules.Validate(a.DataType); err != nil {
+		return err
+	}
+	return a.validateDataTypeChange(tx)
+}
+
+// maxReportedIncompatibleValues caps how many offending values a DataTypeChangeReport lists
+const maxReportedIncompatibleValues = 100
+
+// IncompatibleValue describes a stored SKU attribute value that does not parse under a new data type
+type IncompatibleValue struct {
+	SkuAttributeValueID uint
+	SkuID               uint
+	SkuNumber           string
+	Value               string
+	Error               string `gorm:"-"`
+}
+
+// DataTypeChangeReport summarizes the impact of changing an attribute's data type
+type DataTypeChangeReport struct {
+	AttributeID       uint
+	From              DataType
+	To                DataType
+	CheckedCount      int64
+	IncompatibleCount int64
+	Incompatible      []IncompatibleValue
+}
+
+// DataTypeChangeError is returned when stored values would become invalid under a new data type
+type DataTypeChangeError struct {
+	Report *DataTypeChangeReport
+}
+
+// Error implements the error interface
+func (e *DataTypeChangeError) Error() string {
+	if e.Report.From == e.Report.To {
+		return fmt.Sprintf("cannot change rules of attribute %d: %d stored value(s) are incompatible",
+			e.Report.AttributeID, e.Report.IncompatibleCount)
+	}
+	return fmt.Sprintf("cannot change data type of attribute %d from %s to %s: %d stored value(s) are incompatible",
+		e.Report.AttributeID, e.Report.From, e.Report.To, e.Report.IncompatibleCount)
+}
+
+// CheckDataTypeChange re-validates every stored value of this attribute against
+// the target data type and the attribute's rules, and reports the values that
+// would no longer be valid. At most maxReportedIncompatibleValues offending
+// values are listed.
+func (a *Attribute) CheckDataTypeChange(tx *gorm.DB, to DataType) (*DataTypeChangeReport, error) {
+	var current Attribute
+	if err := tx.Select("id", "data_type").First(&current, a.ID).Error; err != nil {
+		return nil, fmt.Errorf("attribute not found: %w", err)
+	}
+
+	report := &DataTypeChangeReport{
+		AttributeID:  a.ID,
+		From:         current.DataType,
+		To:           to,
+		Incompatible: []IncompatibleValue{},
+	}
+
+	candidate := *a
+	candidate.DataType = to
+	if err := candidate.LoadOptions(tx); err != nil {
+		return nil, err
+	}
+
+	// Walk the stored values in ID order, one batch at a time
+	var lastID uint
+	for {
+		var rows []IncompatibleValue
+		err := tx.Table("sku_attribute_values").
+			Select("sku_attribute_values.id AS sku_attribute_value_id, sku_attribute_values.sku_id, skus.sku_number, sku_attribute_values.value").
+			Joins("JOIN skus ON skus.id = sku_attribute_values.sku_id").
+			Where("sku_attribute_values.attribute_id = ? AND sku_attribute_values.deleted_at IS NULL", a.ID).
+			Where("sku_attribute_values.id > ?", lastID).
+			Order("sku_attribute_values.id").
+			Limit(1000).
+			Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		report.CheckedCount += int64(len(rows))
+		for _, row := range rows {
+			if err := candidate.ValidateValue(row.Value); err != nil {
+				report.IncompatibleCount++
+				if len(report.Incompatible) < maxReportedIncompatibleValues {
+					row.Error = err.Error()
+					report.Incompatible = append(report.Incompatible, row)
+				}
+			}
+		}
+		lastID = rows[len(rows)-1].SkuAttributeValueID
+	}
+
+	return report, nil
+}
+
+// validateDataTypeChange rejects a data type or rules change that would leave
+// stored values invalid
+func (a *Attribute) validateDataTypeChange(tx *gorm.DB) error {
+	if a.ID == 0 {
+		return nil
+	}
+
+	var current Attribute
+	if err := tx.First(&current, a.ID).Error; err != nil {
+		return nil // Nothing stored yet to protect
+	}
+	if current.DataType == a.DataType && current.Rules.Equal(&a.Rules) {
+		return nil
+	}
+
+	report, err := a.CheckDataTypeChange(tx, a.DataType)
+	if err != nil {
+		return err
+	}
+	if report.IncompatibleCount > 0 {
+		return &DataTypeChangeError{Report: report}
+	}
+
+	return nil
+}
+
+// isoDatePattern matches DATE values already stored in canonical form
+const isoDatePattern = `^[0-9]{4}-[0-9]{2}-[0-9]{2}$`
+
+// CanonicalizeDates rewrites the stored values of a DATE attribute that are not
+// in ISO form, e.g. after the attribute became a DATE attribute, so that
+// "4 March 2024" is stored as 2024-03-04. Soft-deleted values are rewritten too,
+// so that restoring their SKU brings them back in canonical form. Values that do
+// not parse are left alone; a data type change rejects them beforehand.
+func (a *Attribute) CanonicalizeDates(tx *gorm.DB) (int64, error) {
+	if a.DataType != DataTypeDate {
+		return 0, nil
+	}
+
+	var values []SkuAttributeValue
+	var rewritten int64
+	err := tx.Unscoped().Select("id", "value").
+		Where("attribute_id = ? AND value !~ ?", a.ID, isoDatePattern).
+		FindInBatches(&values, 500, func(_ *gorm.DB, _ int) error {
+			updates := make([]storedValueUpdate, 0, len(values))
+			for _, value := range values {
+				parsed, err := a.ParseValue(value.Value)
+				if err != nil {
+					continue
+				}
+				updates = append(updates, storedValueUpdate{ID: value.ID, Value: parsed.(time.Time).Format(utils.DateLayout)})
+			}
+			rewritten += int64(len(updates))
+			return updateStoredValues(tx, updates)
+		}).Error
+	return rewritten, err
+}
+
+// storedValueUpdate is the new form of a stored SKU value and its base value
+type storedValueUpdate struct {
+	ID        uint
+	Value     string
+	BaseValue *float64
+}
+
+// updateStoredValues writes the value and base value of each update in a single
+// statement. Hooks are skipped and updated_at is left untouched, as the values
+// only change form.
+func updateStoredValues(tx *gorm.DB, updates []storedValueUpdate) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	rows := make([]string, len(updates))
+	args := make([]interface{}, 0, 3*len(updates))
+	for i, update := range updates {
+		rows[i] = "(?::bigint, ?::text, ?::double precision)"
+		args = append(args, update.ID, update.Value, update.BaseValue)
+	}
+	return tx.Exec("UPDATE sku_attribute_values AS v SET value = u.value, base_value = u.base_value FROM (VALUES "+
+		strings.Join(rows, ", ")+") AS u(id, value, base_value) WHERE v.id = u.id", args...).Error
 }
 
 // GetTableName returns the table name for database operations
@@ -60,44 +256,40 @@ func (a *Attribute) GetTableName() string {
 
 // Helper methods for working with different data types
 
-// ParseValue parses the string value according to the attribute's data type
+// ParseValue parses the string value according to the attribute's data type.
+// OPTION values parse to the matching AttributeOption and need the options loaded
+// (see LoadOptions).
 func (a *Attribute) ParseValue(valueStr string) (interface{}, error) {
 	switch a.DataType {
 	case DataTypeText:
 		return valueStr, nil
 
 	case DataTypeNumber:
-		return strconv.ParseFloat(valueStr, 64)
+		return a.parseNumber(valueStr)
 
 	case DataTypeBoolean:
 		return strconv.ParseBool(valueStr)
 
 	case DataTypeDate:
-		// Try multiple date formats
-		formats := []string{
-			"2006-01-02",          // ISO date: 2023-12-31
-			"2006-01-02 15:04:05", // ISO datetime: 2023-12-31 23:59:59
-			time.RFC3339,          // RFC3339: 2023-12-31T23:59:59Z
-			"02/01/2006",          // DD/MM/YYYY: 31/12/2023
-			"01/02/2006",          // MM/DD/YYYY: 12/31/2023
-		}
-
-		var lastErr error
-		for _, format := range formats {
-			if t, err := time.Parse(format, valueStr); err == nil {
-				return t, nil
-			} else {
-				lastErr = err
-			}
+		// Numeric dates that read differently day-first and month-first are
+		// rejected; callers that know the locale parse them with utils.ParseDate
+		return utils.ParseDate(valueStr, "")
+
+	case DataTypeOption:
+		option, ok := a.FindOption(valueStr)
+		if !ok {
+			return nil, a.unknownOptionError(valueStr)
 		}
-		return nil, fmt.Errorf("invalid date format: %w", lastErr)
+		return *option, nil
 
 	default:
 		return nil, fmt.Errorf("unsupported data type: %s", a.DataType)
 	}
 }
 
-// FormatValue formats a value to string for storage
+// FormatValue formats a value to string for storage. Numbers are stored without
+// losing digits: a float is written in the shortest form that parses back to the
+// same value, e.g. 2.625 or 0.001.
 func (a *Attribute) FormatValue(value interface{}) (string, error) {
 	switch a.DataType {
 	case DataTypeText:
@@ -107,20 +299,22 @@ func (a *Attribute) FormatValue(value interface{}) (string, error) {
 		return fmt.Sprintf("%v", value), nil
 
 	case DataTypeNumber:
+		var formatted string
 		switch v := value.(type) {
-		case int:
-			return fmt.Sprintf("%d", v), nil
-		case int32:
-			return fmt.Sprintf("%d", v), nil
-		case int64:
-			return fmt.Sprintf("%d", v), nil
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			formatted = fmt.Sprintf("%d", v)
 		case float32:
-			return fmt.Sprintf("%.2f", v), nil
+			formatted = formatNumber(float64(v), 32)
 		case float64:
-			return fmt.Sprintf("%.2f", v), nil
+			formatted = formatNumber(v, 64)
 		default:
 			return "", fmt.Errorf("invalid number value: %v", value)
 		}
+		// Reject digits beyond the attribute's precision instead of rounding them away
+		if err := a.checkPrecision(formatted); err != nil {
+			return "", err
+		}
+		return formatted, nil
 
 	case DataTypeBoolean:
 		if b, ok := value.(bool); ok {
@@ -131,10 +325,10 @@ func (a *Attribute) FormatValue(value interface{}) (string, error) {
 	case DataTypeDate:
 		switch v := value.(type) {
 		case time.Time:
-			return v.Format("2006-01-02"), nil
+			return v.Format(utils.DateLayout), nil
 		case string:
 			// Validate it's a valid date string
-			if _, err := time.Parse("2006-01-02", v); err == nil {
+			if _, err := time.Parse(utils.DateLayout, v); err == nil {
 				return v, nil
 			}
 			return "", fmt.Errorf("invalid date string format, use YYYY-MM-DD: %s", v)
@@ -142,17 +336,125 @@ func (a *Attribute) FormatValue(value interface{}) (string, error) {
 			return "", fmt.Errorf("invalid date value: %v", value)
 		}
 
+	case DataTypeOption:
+		switch v := value.(type) {
+		case AttributeOption:
+			return v.Code, nil
+		case *AttributeOption:
+			return v.Code, nil
+		case string:
+			option, ok := a.FindOption(v)
+			if !ok {
+				return "", a.unknownOptionError(v)
+			}
+			return option.Code, nil
+		default:
+			return "", fmt.Errorf("invalid option value: %v", value)
+		}
+
 	default:
 		return "", fmt.Errorf("unsupported data type: %s", a.DataType)
 	}
 }
 
-// ValidateValue validates if a value is valid for this attribute's data type
+// ValidateValue validates if a value is valid for this attribute's data type and rules
 func (a *Attribute) ValidateValue(valueStr string) error {
-	_, err := a.ParseValue(valueStr)
+	parsed, err := a.ParseValue(valueStr)
+	if err != nil {
+		return err
+	}
+	return a.CheckRules(parsed)
+}
+
+// ParseValues parses the ordered value list of a SKU and checks every value
+// against the rules. A single-valued attribute takes exactly one value; a
+// multi-valued attribute takes one or more values, none of which may repeat
+// another (compared after parsing, so "Red" and "red" are the same option).
+func (a *Attribute) ParseValues(values []string) ([]interface{}, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("at least one value is required")
+	}
+	if !a.MultiValued && len(values) > 1 {
+		return nil, fmt.Errorf("attribute takes a single value, got %d", len(values))
+	}
+
+	parsed := make([]interface{}, len(values))
+	seen := make(map[string]int, len(values))
+	for i, value := range values {
+		v, err := a.ParseValue(value)
+		if err == nil {
+			err = a.CheckRules(v)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("value %d: %w", i+1, err)
+		}
+		key := fmt.Sprint(v)
+		if first, ok := seen[key]; ok {
+			return nil, fmt.Errorf("value %d repeats value %d (%q)", i+1, first+1, value)
+		}
+		seen[key] = i
+		parsed[i] = v
+	}
+
+	return parsed, nil
+}
+
+// ValidateValues validates the ordered value list of a SKU, see ParseValues
+func (a *Attribute) ValidateValues(values []string) error {
+	_, err := a.ParseValues(values)
 	return err
 }
 
+// FormatValues formats a value or a slice of values to their stored strings, in
+// order. Only multi-valued attributes accept more than one value.
+func (a *Attribute) FormatValues(value interface{}) ([]string, error) {
+	items := []interface{}{value}
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Slice {
+		items = make([]interface{}, rv.Len())
+		for i := range items {
+			items[i] = rv.Index(i).Interface()
+		}
+	}
+	if !a.MultiValued && len(items) != 1 {
+		return nil, fmt.Errorf("attribute takes a single value, got %d", len(items))
+	}
+
+	formatted := make([]string, len(items))
+	for i, item := range items {
+		str, err := a.FormatValue(item)
+		if err != nil {
+			return nil, err
+		}
+		formatted[i] = str
+	}
+
+	return formatted, nil
+}
+
+// DisplayValues joins stored values for display: options are shown by their
+// label (see LoadOptions), numbers with the attribute's precision as fixed
+// decimal places, and the UOM is added once after the list, as in "8, 16 GB"
+func (a *Attribute) DisplayValues(values ...string) string {
+	display := make([]string, len(values))
+	for i, value := range values {
+		display[i] = value
+		switch a.DataType {
+		case DataTypeOption:
+			if option, ok := a.FindOption(value); ok {
+				display[i] = option.Label
+			}
+		case DataTypeNumber:
+			display[i] = a.displayNumber(value)
+		}
+	}
+
+	joined := strings.Join(display, ", ")
+	if a.UOM != "" {
+		return fmt.Sprintf("%s %s", joined, a.UOM)
+	}
+	return joined
+}
+
 // MarshalJSON custom JSON marshaling
 func (a *Attribute) MarshalJSON() ([]byte, error) {
 	type Alias Attribute
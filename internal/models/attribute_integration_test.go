@@ -4,6 +4,7 @@
 package models_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/Wilson1510/klampis-pim-go/internal/models"
@@ -791,3 +792,86 @@ func TestAttributeValidateValue_Integration(t *testing.T) {
 	})
 }
 
+
+func TestAttributeDataTypeChange_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	// Create a test user for CreatedBy/UpdatedBy
+	testUser := models.User{
+		Username: "testuser",
+		Password: "password123",
+		Name:     "Test User",
+		Role:     models.RoleUser,
+	}
+	if err := db.Create(&testUser).Error; err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+
+	audit := models.Base{CreatedBy: testUser.ID, UpdatedBy: testUser.ID}
+
+	category := models.Category{Name: "Electronics", Base: audit}
+	db.Create(&category)
+	product := models.Product{Name: "Laptop", CategoryID: category.ID, Base: audit}
+	db.Create(&product)
+	sku1 := models.Sku{Name: "Laptop - A", SkuNumber: "LAP-A", Price: 100, ProductID: product.ID, Base: audit}
+	db.Create(&sku1)
+	sku2 := models.Sku{Name: "Laptop - B", SkuNumber: "LAP-B", Price: 100, ProductID: product.ID, Base: audit}
+	db.Create(&sku2)
+
+	sizeAttr := models.Attribute{Name: "Size", Code: "size", DataType: models.DataTypeText, Base: audit}
+	if err := db.Create(&sizeAttr).Error; err != nil {
+		t.Fatalf("Failed to create attribute: %v", err)
+	}
+
+	values := []models.SkuAttributeValue{
+		{SkuID: sku1.ID, AttributeID: sizeAttr.ID, Value: "15.6", CreatedBy: testUser.ID, UpdatedBy: testUser.ID},
+		{SkuID: sku2.ID, AttributeID: sizeAttr.ID, Value: "Large", CreatedBy: testUser.ID, UpdatedBy: testUser.ID},
+	}
+	for i := range values {
+		if err := db.Create(&values[i]).Error; err != nil {
+			t.Fatalf("Failed to create attribute value: %v", err)
+		}
+	}
+
+	t.Run("Report incompatible values", func(t *testing.T) {
+		report, err := sizeAttr.CheckDataTypeChange(db, models.DataTypeNumber)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if report.CheckedCount != 2 {
+			t.Errorf("Expected 2 checked values, got %d", report.CheckedCount)
+		}
+		if report.IncompatibleCount != 1 {
+			t.Fatalf("Expected 1 incompatible value, got %d", report.IncompatibleCount)
+		}
+		if report.Incompatible[0].SkuNumber != "LAP-B" {
+			t.Errorf("Expected offending SKU 'LAP-B', got '%s'", report.Incompatible[0].SkuNumber)
+		}
+	})
+
+	t.Run("Reject incompatible data type change", func(t *testing.T) {
+		sizeAttr.DataType = models.DataTypeNumber
+		err := db.Save(&sizeAttr).Error
+
+		var changeErr *models.DataTypeChangeError
+		if !errors.As(err, &changeErr) {
+			t.Fatalf("Expected DataTypeChangeError but got: %v", err)
+		}
+
+		var stored models.Attribute
+		db.First(&stored, sizeAttr.ID)
+		if stored.DataType != models.DataTypeText {
+			t.Errorf("Expected data type to remain TEXT, got %s", stored.DataType)
+		}
+	})
+
+	t.Run("Allow compatible data type change", func(t *testing.T) {
+		db.Model(&values[1]).Update("value", "17.3")
+
+		sizeAttr.DataType = models.DataTypeNumber
+		if err := db.Save(&sizeAttr).Error; err != nil {
+			t.Errorf("Expected no error but got: %v", err)
+		}
+	})
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// AttributeOption is an allowed value of an OPTION attribute. SKU attribute
+// values of an OPTION attribute store the option code; the label is shown to
+// customers and Sequence orders the options.
+type AttributeOption struct {
+	Base
+	AttributeID uint   `gorm:"not null;uniqueIndex:idx_attribute_option_code,priority:1" json:"attribute_id"`
+	Code        string `gorm:"not null;type:varchar(70);uniqueIndex:idx_attribute_option_code,priority:2" json:"code"`
+	Label       string `gorm:"not null;type:varchar(100)" json:"label"`
+
+	// Relationships
+	Attribute *Attribute `gorm:"foreignKey:AttributeID" json:"attribute,omitempty"`
+}
+
+// TableName specifies the table name for AttributeOption
+func (AttributeOption) TableName() string {
+	return "attribute_options"
+}
+
+// maxListedOptions caps how many option codes an unknown option error lists
+const maxListedOptions = 20
+
+// LoadOptions loads the options of an OPTION attribute, ordered by sequence,
+// unless they are already loaded
+func (a *Attribute) LoadOptions(tx *gorm.DB) error {
+	if a.DataType != DataTypeOption || a.Options != nil {
+		return nil
+	}
+	return tx.Where("attribute_id = ?", a.ID).Order("sequence, id").Find(&a.Options).Error
+}
+
+// FindOption returns the option matching value. The code has to match exactly,
+// or else the code or label ignoring case and surrounding spaces, so that "Red",
+// "red" and "RED " all resolve to the same option.
+func (a *Attribute) FindOption(value string) (*AttributeOption, bool) {
+	for i := range a.Options {
+		if a.Options[i].Code == value {
+			return &a.Options[i], true
+		}
+	}
+
+	value = strings.TrimSpace(value)
+	for i := range a.Options {
+		if strings.EqualFold(a.Options[i].Code, value) || strings.EqualFold(a.Options[i].Label, value) {
+			return &a.Options[i], true
+		}
+	}
+	return nil, false
+}
+
+// unknownOptionError describes a value that is not an option of the attribute
+func (a *Attribute) unknownOptionError(value string) error {
+	codes := make([]string, 0, min(len(a.Options), maxListedOptions))
+	for _, option := range a.Options[:min(len(a.Options), maxListedOptions)] {
+		codes = append(codes, option.Code)
+	}
+	if len(a.Options) > maxListedOptions {
+		codes = append(codes, "...")
+	}
+	return fmt.Errorf("%q is not an option of this attribute, allowed: %s", value, strings.Join(codes, ", "))
+}
@@ -0,0 +1,240 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// ruleDateFormat is the format of the date limits in rule errors
+const ruleDateFormat = "2006-01-02"
+
+// AttributeRules are optional constraints a value has to meet on top of parsing
+// as the attribute's data type. Every rule belongs to one data type; unset rules
+// are not checked.
+type AttributeRules struct {
+	MinValue  *float64   `json:"min_value,omitempty"`                        // NUMBER
+	MaxValue  *float64   `json:"max_value,omitempty"`                        // NUMBER
+	Precision *int       `json:"precision,omitempty"`                        // NUMBER: maximum number of decimal places
+	MinLength *int       `json:"min_length,omitempty"`                       // TEXT, in characters
+	MaxLength *int       `json:"max_length,omitempty"`                       // TEXT, in characters
+	Pattern   string     `gorm:"type:varchar(255)" json:"pattern,omitempty"` // TEXT: regular expression the whole value has to match
+	MinDate   *time.Time `gorm:"type:date" json:"min_date,omitempty"`        // DATE
+	MaxDate   *time.Time `gorm:"type:date" json:"max_date,omitempty"`        // DATE
+}
+
+// RuleViolationError is returned when a value breaks a rule of its attribute
+type RuleViolationError struct {
+	Rule    string      // JSON name of the rule, e.g. max_length
+	Limit   interface{} // The configured limit
+	Message string
+}
+
+// Error implements the error interface
+func (e *RuleViolationError) Error() string {
+	return e.Message
+}
+
+// InvalidRuleError is returned when a rule is misconfigured
+type InvalidRuleError struct {
+	Rule    string // JSON name of the rule, e.g. pattern
+	Message string
+}
+
+// Error implements the error interface
+func (e *InvalidRuleError) Error() string {
+	return fmt.Sprintf("invalid rule %s: %s", e.Rule, e.Message)
+}
+
+// Validate checks that the rules fit the data type and are consistent
+func (r *AttributeRules) Validate(dataType DataType) error {
+	requireType := func(rule string, set bool, expected DataType) error {
+		if set && dataType != expected {
+			return &InvalidRuleError{Rule: rule, Message: fmt.Sprintf("only applies to %s attributes", expected)}
+		}
+		return nil
+	}
+	checks := []error{
+		requireType("min_value", r.MinValue != nil, DataTypeNumber),
+		requireType("max_value", r.MaxValue != nil, DataTypeNumber),
+		requireType("precision", r.Precision != nil, DataTypeNumber),
+		requireType("min_length", r.MinLength != nil, DataTypeText),
+		requireType("max_length", r.MaxLength != nil, DataTypeText),
+		requireType("pattern", r.Pattern != "", DataTypeText),
+		requireType("min_date", r.MinDate != nil, DataTypeDate),
+		requireType("max_date", r.MaxDate != nil, DataTypeDate),
+	}
+	for _, err := range checks {
+		if err != nil {
+			return err
+		}
+	}
+
+	if r.MinValue != nil && r.MaxValue != nil && *r.MinValue > *r.MaxValue {
+		return &InvalidRuleError{Rule: "max_value", Message: "must not be less than min_value"}
+	}
+	if r.Precision != nil && *r.Precision < 0 {
+		return &InvalidRuleError{Rule: "precision", Message: "must not be negative"}
+	}
+	if r.MinLength != nil && *r.MinLength < 0 {
+		return &InvalidRuleError{Rule: "min_length", Message: "must not be negative"}
+	}
+	if r.MaxLength != nil && *r.MaxLength < 1 {
+		return &InvalidRuleError{Rule: "max_length", Message: "must be at least 1"}
+	}
+	if r.MinLength != nil && r.MaxLength != nil && *r.MinLength > *r.MaxLength {
+		return &InvalidRuleError{Rule: "max_length", Message: "must not be less than min_length"}
+	}
+	if r.Pattern != "" {
+		if _, err := compileRulePattern(r.Pattern); err != nil {
+			return &InvalidRuleError{Rule: "pattern", Message: err.Error()}
+		}
+	}
+	if r.MinDate != nil && r.MaxDate != nil && r.MinDate.Format(ruleDateFormat) > r.MaxDate.Format(ruleDateFormat) {
+		return &InvalidRuleError{Rule: "max_date", Message: "must not be before min_date"}
+	}
+
+	return nil
+}
+
+// CheckRules checks a value that parsed as the attribute's data type against the
+// attribute's rules, returning a RuleViolationError for the first broken rule
+func (a *Attribute) CheckRules(parsed interface{}) error {
+	r := &a.Rules
+
+	switch v := parsed.(type) {
+	case float64:
+		if r.MinValue != nil && v < *r.MinValue {
+			return violation("min_value", *r.MinValue, "must be at least %v", *r.MinValue)
+		}
+		if r.MaxValue != nil && v > *r.MaxValue {
+			return violation("max_value", *r.MaxValue, "must be at most %v", *r.MaxValue)
+		}
+		if r.Precision != nil && decimalPlaces(v) > *r.Precision {
+			return violation("precision", *r.Precision, "must have at most %d decimal place(s)", *r.Precision)
+		}
+
+	case string:
+		length := utf8.RuneCountInString(v)
+		if r.MinLength != nil && length < *r.MinLength {
+			return violation("min_length", *r.MinLength, "must be at least %d character(s) long", *r.MinLength)
+		}
+		if r.MaxLength != nil && length > *r.MaxLength {
+			return violation("max_length", *r.MaxLength, "must be at most %d character(s) long", *r.MaxLength)
+		}
+		if r.Pattern != "" {
+			pattern, err := compileRulePattern(r.Pattern)
+			if err != nil {
+				return err
+			}
+			if !pattern.MatchString(v) {
+				return violation("pattern", r.Pattern, "must match the pattern %s", r.Pattern)
+			}
+		}
+
+	case time.Time:
+		date := v.Format(ruleDateFormat)
+		if r.MinDate != nil && date < r.MinDate.Format(ruleDateFormat) {
+			limit := r.MinDate.Format(ruleDateFormat)
+			return violation("min_date", limit, "must not be before %s", limit)
+		}
+		if r.MaxDate != nil && date > r.MaxDate.Format(ruleDateFormat) {
+			limit := r.MaxDate.Format(ruleDateFormat)
+			return violation("max_date", limit, "must not be after %s", limit)
+		}
+	}
+
+	return nil
+}
+
+// violation builds a RuleViolationError
+func violation(rule string, limit interface{}, format string, args ...interface{}) error {
+	return &RuleViolationError{Rule: rule, Limit: limit, Message: fmt.Sprintf(format, args...)}
+}
+
+// decimalPlaces returns the number of decimal places of the shortest
+// representation of v, so that 1.50 has one and 2e-3 has three
+func decimalPlaces(v float64) int {
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
+
+// rulePatterns caches compiled rule patterns, as every value of an attribute is
+// checked against the same pattern
+var rulePatterns sync.Map
+
+// compileRulePattern compiles a rule pattern so that it matches the whole value
+func compileRulePattern(pattern string) (*regexp.Regexp, error) {
+	if cached, ok := rulePatterns.Load(pattern); ok {
+		return cached.(*regexp.Regexp), nil
+	}
+	compiled, err := regexp.Compile(`^(?:` + pattern + `)$`)
+	if err != nil {
+		return nil, err
+	}
+	rulePatterns.Store(pattern, compiled)
+	return compiled, nil
+}
+
+// Equal reports whether both rule sets constrain values the same way
+func (r *AttributeRules) Equal(other *AttributeRules) bool {
+	sameDate := func(a, b *time.Time) bool {
+		if a == nil || b == nil {
+			return a == b
+		}
+		return a.Format(ruleDateFormat) == b.Format(ruleDateFormat)
+	}
+	return equalPtr(r.MinValue, other.MinValue) && equalPtr(r.MaxValue, other.MaxValue) &&
+		equalPtr(r.Precision, other.Precision) &&
+		equalPtr(r.MinLength, other.MinLength) && equalPtr(r.MaxLength, other.MaxLength) &&
+		r.Pattern == other.Pattern &&
+		sameDate(r.MinDate, other.MinDate) && sameDate(r.MaxDate, other.MaxDate)
+}
+
+// equalPtr reports whether two optional values are both unset or equal
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// formatNumber formats v in the shortest form that parses back to the same
+// float of the given bit size, without an exponent
+func formatNumber(v float64, bitSize int) string {
+	return strconv.FormatFloat(v, 'f', -1, bitSize)
+}
+
+// checkPrecision rejects a formatted number with more decimal places than the
+// attribute's precision allows
+func (a *Attribute) checkPrecision(formatted string) error {
+	v, err := strconv.ParseFloat(formatted, 64)
+	if err != nil {
+		return fmt.Errorf("invalid number value: %s", formatted)
+	}
+	if a.Rules.Precision != nil && decimalPlaces(v) > *a.Rules.Precision {
+		return violation("precision", *a.Rules.Precision, "%s has more than %d decimal place(s)", formatted, *a.Rules.Precision)
+	}
+	return nil
+}
+
+// displayNumber formats a stored number with the attribute's precision as fixed
+// decimal places, so that 2.5 GHz with precision 2 shows as 2.50. Numbers with
+// more decimal places than the precision are shown as stored rather than rounded.
+func (a *Attribute) displayNumber(value string) string {
+	if a.Rules.Precision == nil {
+		return value
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil || decimalPlaces(v) > *a.Rules.Precision {
+		return value
+	}
+	return strconv.FormatFloat(v, 'f', *a.Rules.Precision, 64)
+}
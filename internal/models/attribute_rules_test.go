@@ -0,0 +1,113 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestAttributeCheckRules tests that values are checked against the attribute rules
+func TestAttributeCheckRules(t *testing.T) {
+	zero, two, one, fifty := 0.0, 2, 1, 50
+	minDate := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	screen := Attribute{DataType: DataTypeNumber, Rules: AttributeRules{MinValue: &zero, Precision: &two}}
+	color := Attribute{DataType: DataTypeText, Rules: AttributeRules{MinLength: &one, MaxLength: &fifty}}
+	model := Attribute{DataType: DataTypeText, Rules: AttributeRules{Pattern: `[A-Z]{2}-[0-9]+`}}
+	released := Attribute{DataType: DataTypeDate, Rules: AttributeRules{MinDate: &minDate}}
+
+	testCases := []struct {
+		name         string
+		attribute    Attribute
+		valueStr     string
+		expectedRule string
+	}{
+		{name: "Number within bounds", attribute: screen, valueStr: "15.6"},
+		{name: "Negative screen size", attribute: screen, valueStr: "-3", expectedRule: "min_value"},
+		{name: "Too many decimal places", attribute: screen, valueStr: "15.625", expectedRule: "precision"},
+		{name: "Trailing zeros do not count", attribute: screen, valueStr: "15.600"},
+		{name: "Text within length", attribute: color, valueStr: "Space Gray"},
+		{name: "Text too long", attribute: color, valueStr: strings.Repeat("x", 5000), expectedRule: "max_length"},
+		{name: "Length counts characters", attribute: color, valueStr: strings.Repeat("é", 50)},
+		{name: "Pattern matches", attribute: model, valueStr: "AB-123"},
+		{name: "Pattern matches only part", attribute: model, valueStr: "xAB-123", expectedRule: "pattern"},
+		{name: "Date after minimum", attribute: released, valueStr: "2021-06-01"},
+		{name: "Date before minimum", attribute: released, valueStr: "2019-12-31", expectedRule: "min_date"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.attribute.ValidateValue(tc.valueStr)
+
+			if tc.expectedRule == "" {
+				if err != nil {
+					t.Errorf("Expected no error, but got: %v", err)
+				}
+				return
+			}
+
+			var violation *RuleViolationError
+			if !errors.As(err, &violation) {
+				t.Fatalf("Expected RuleViolationError, got: %v", err)
+			}
+			if violation.Rule != tc.expectedRule {
+				t.Errorf("Expected rule '%s', got '%s'", tc.expectedRule, violation.Rule)
+			}
+		})
+	}
+}
+
+// TestAttributeRulesValidate tests that misconfigured rules are rejected
+func TestAttributeRulesValidate(t *testing.T) {
+	low, high, negative := 1.0, 10.0, -1
+
+	testCases := []struct {
+		name         string
+		dataType     DataType
+		rules        AttributeRules
+		expectedRule string
+	}{
+		{name: "No rules", dataType: DataTypeBoolean},
+		{name: "Number range", dataType: DataTypeNumber, rules: AttributeRules{MinValue: &low, MaxValue: &high}},
+		{name: "Rule of another data type", dataType: DataTypeText, rules: AttributeRules{MinValue: &low}, expectedRule: "min_value"},
+		{name: "Inverted range", dataType: DataTypeNumber, rules: AttributeRules{MinValue: &high, MaxValue: &low}, expectedRule: "max_value"},
+		{name: "Negative precision", dataType: DataTypeNumber, rules: AttributeRules{Precision: &negative}, expectedRule: "precision"},
+		{name: "Invalid pattern", dataType: DataTypeText, rules: AttributeRules{Pattern: "[a-"}, expectedRule: "pattern"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.rules.Validate(tc.dataType)
+
+			if tc.expectedRule == "" {
+				if err != nil {
+					t.Errorf("Expected no error, but got: %v", err)
+				}
+				return
+			}
+
+			var invalid *InvalidRuleError
+			if !errors.As(err, &invalid) {
+				t.Fatalf("Expected InvalidRuleError, got: %v", err)
+			}
+			if invalid.Rule != tc.expectedRule {
+				t.Errorf("Expected rule '%s', got '%s'", tc.expectedRule, invalid.Rule)
+			}
+		})
+	}
+}
+
+// TestAttributeRulesEqual tests comparison of rule sets
+func TestAttributeRulesEqual(t *testing.T) {
+	a, b := 5, 5
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sameDay := time.Date(2024, 1, 1, 0, 0, 0, 0, time.FixedZone("WIB", 7*3600))
+
+	if !(&AttributeRules{MaxLength: &a, MinDate: &date}).Equal(&AttributeRules{MaxLength: &b, MinDate: &sameDay}) {
+		t.Error("Expected rules with equal limits to be equal")
+	}
+	if (&AttributeRules{MaxLength: &a}).Equal(&AttributeRules{}) {
+		t.Error("Expected a removed rule to make the rules differ")
+	}
+}
@@ -54,6 +54,16 @@ func TestAttributeDataTypeValidation(t *testing.T) {
 			expectError: false,
 			description: "DATE data type should be valid",
 		},
+		{
+			name: "Valid OPTION data type",
+			attribute: Attribute{
+				Name:     "Color",
+				Code:     "color",
+				DataType: DataTypeOption,
+			},
+			expectError: false,
+			description: "OPTION data type should be valid",
+		},
 		{
 			name: "Invalid data type",
 			attribute: Attribute{
@@ -132,6 +142,20 @@ func TestAttributeParseValue(t *testing.T) {
 			expected:    time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
 			expectError: false,
 		},
+		{
+			name:        "Parse unambiguous numeric date",
+			attribute:   Attribute{DataType: DataTypeDate},
+			valueStr:    "12/31/2023",
+			expected:    time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+			expectError: false,
+		},
+		{
+			name:        "Parse ambiguous numeric date",
+			attribute:   Attribute{DataType: DataTypeDate},
+			valueStr:    "03/04/2024",
+			expected:    nil,
+			expectError: true,
+		},
 		{
 			name:        "Parse invalid number",
 			attribute:   Attribute{DataType: DataTypeNumber},
@@ -205,6 +229,40 @@ func TestAttributeFormatValue(t *testing.T) {
 			expected:    "15.99",
 			expectError: false,
 		},
+		{
+			name:        "Format number value keeps every decimal",
+			attribute:   Attribute{DataType: DataTypeNumber},
+			value:       2.625,
+			expected:    "2.625",
+			expectError: false,
+		},
+		{
+			name:        "Format small number value",
+			attribute:   Attribute{DataType: DataTypeNumber},
+			value:       0.001,
+			expected:    "0.001",
+			expectError: false,
+		},
+		{
+			name:        "Format number value (float32)",
+			attribute:   Attribute{DataType: DataTypeNumber},
+			value:       float32(0.1),
+			expected:    "0.1",
+			expectError: false,
+		},
+		{
+			name:        "Format number value (uint8)",
+			attribute:   Attribute{DataType: DataTypeNumber},
+			value:       uint8(8),
+			expected:    "8",
+			expectError: false,
+		},
+		{
+			name:        "Format number value beyond precision",
+			attribute:   Attribute{DataType: DataTypeNumber, Rules: AttributeRules{Precision: intPtr(2)}},
+			value:       2.625,
+			expectError: true,
+		},
 		{
 			name:        "Format boolean true",
 			attribute:   Attribute{DataType: DataTypeBoolean},
@@ -331,4 +389,157 @@ func TestAttributeDataTypeConstants(t *testing.T) {
 	if DataTypeDate != "DATE" {
 		t.Errorf("Expected DataTypeDate to be 'DATE', got '%s'", DataTypeDate)
 	}
+
+	if DataTypeOption != "OPTION" {
+		t.Errorf("Expected DataTypeOption to be 'OPTION', got '%s'", DataTypeOption)
+	}
+}
+
+// TestAttributeOptionValues tests parsing and formatting of OPTION values
+func TestAttributeOptionValues(t *testing.T) {
+	attribute := Attribute{
+		DataType: DataTypeOption,
+		Options: []AttributeOption{
+			{Code: "red", Label: "Red"},
+			{Code: "navy", Label: "Navy Blue"},
+		},
+	}
+
+	testCases := []struct {
+		name         string
+		valueStr     string
+		expectedCode string
+		expectError  bool
+	}{
+		{name: "Exact code", valueStr: "red", expectedCode: "red"},
+		{name: "Code ignoring case and spaces", valueStr: " RED ", expectedCode: "red"},
+		{name: "Label", valueStr: "navy blue", expectedCode: "navy"},
+		{name: "Unknown option", valueStr: "green", expectError: true},
+		{name: "Empty value", valueStr: "", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := attribute.ParseValue(tc.valueStr)
+			formatted, formatErr := attribute.FormatValue(tc.valueStr)
+
+			if tc.expectError {
+				if err == nil || formatErr == nil {
+					t.Errorf("Expected error for value '%s', but got parse=%v format=%v", tc.valueStr, err, formatErr)
+				}
+				return
+			}
+
+			if err != nil || formatErr != nil {
+				t.Fatalf("Expected no error for value '%s', but got parse=%v format=%v", tc.valueStr, err, formatErr)
+			}
+			if option, ok := parsed.(AttributeOption); !ok || option.Code != tc.expectedCode {
+				t.Errorf("Expected option '%s', got %v", tc.expectedCode, parsed)
+			}
+			if formatted != tc.expectedCode {
+				t.Errorf("Expected formatted value '%s', got '%s'", tc.expectedCode, formatted)
+			}
+		})
+	}
+
+	if formatted, err := attribute.FormatValue(&attribute.Options[1]); err != nil || formatted != "navy" {
+		t.Errorf("Expected an option to format to its code, got '%s' (%v)", formatted, err)
+	}
+}
+
+// TestAttributeParseValues tests parsing of value lists
+func TestAttributeParseValues(t *testing.T) {
+	single := Attribute{DataType: DataTypeNumber}
+	multi := Attribute{DataType: DataTypeText, MultiValued: true}
+	colors := Attribute{
+		DataType:    DataTypeOption,
+		MultiValued: true,
+		Options:     []AttributeOption{{Code: "red", Label: "Red"}, {Code: "blue", Label: "Blue"}},
+	}
+
+	testCases := []struct {
+		name        string
+		attribute   Attribute
+		values      []string
+		expectError bool
+	}{
+		{name: "Single value", attribute: single, values: []string{"16"}},
+		{name: "Several values for single-valued attribute", attribute: single, values: []string{"16", "32"}, expectError: true},
+		{name: "No values", attribute: multi, values: nil, expectError: true},
+		{name: "Ordered list", attribute: multi, values: []string{"iOS", "Android", "Windows"}},
+		{name: "Repeated value", attribute: multi, values: []string{"iOS", "Android", "iOS"}, expectError: true},
+		{name: "Same option spelled differently", attribute: colors, values: []string{"red", "RED"}, expectError: true},
+		{name: "Invalid item", attribute: colors, values: []string{"red", "green"}, expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := tc.attribute.ParseValues(tc.values)
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Expected error for values %v, but got nil", tc.values)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error for values %v, but got: %v", tc.values, err)
+			}
+			if len(parsed) != len(tc.values) {
+				t.Errorf("Expected %d parsed values, got %d", len(tc.values), len(parsed))
+			}
+		})
+	}
+}
+
+// TestAttributeFormatValues tests formatting of value lists
+func TestAttributeFormatValues(t *testing.T) {
+	multi := Attribute{DataType: DataTypeNumber, MultiValued: true}
+
+	formatted, err := multi.FormatValues([]int{8, 16})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(formatted) != 2 || formatted[0] != "8" || formatted[1] != "16" {
+		t.Errorf("Expected [8 16], got %v", formatted)
+	}
+
+	formatted, err = multi.FormatValues(32)
+	if err != nil || len(formatted) != 1 || formatted[0] != "32" {
+		t.Errorf("Expected a single value to format to [32], got %v (%v)", formatted, err)
+	}
+
+	single := Attribute{DataType: DataTypeNumber}
+	if _, err := single.FormatValues([]int{8, 16}); err == nil {
+		t.Error("Expected a single-valued attribute to reject a list")
+	}
+}
+
+// TestAttributeDisplayValues tests display of value lists
+func TestAttributeDisplayValues(t *testing.T) {
+	cpu := Attribute{DataType: DataTypeNumber, UOM: "GHz", Rules: AttributeRules{Precision: intPtr(2)}}
+	if got := cpu.DisplayValues("2.5"); got != "2.50 GHz" {
+		t.Errorf("Expected '2.50 GHz', got '%s'", got)
+	}
+	if got := cpu.DisplayValues("2.625"); got != "2.625 GHz" {
+		t.Errorf("Expected a value beyond the precision to be shown as stored, got '%s'", got)
+	}
+
+	ram := Attribute{DataType: DataTypeNumber, UOM: "GB", MultiValued: true}
+	if got := ram.DisplayValues("8", "16"); got != "8, 16 GB" {
+		t.Errorf("Expected '8, 16 GB', got '%s'", got)
+	}
+
+	colors := Attribute{
+		DataType: DataTypeOption,
+		Options:  []AttributeOption{{Code: "red", Label: "Red"}, {Code: "navy", Label: "Navy Blue"}},
+	}
+	if got := colors.DisplayValues("navy", "red"); got != "Navy Blue, Red" {
+		t.Errorf("Expected 'Navy Blue, Red', got '%s'", got)
+	}
+}
+
+func intPtr(v int) *int {
+	return &v
 }
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RefreshToken is a persisted, single-use refresh token. Only the SHA-256 hash
+// of the token is stored; the raw value is handed to the client once.
+type RefreshToken struct {
+	gorm.Model
+	UserID     uint       `gorm:"not null;index" json:"user_id"`
+	TokenHash  string     `gorm:"uniqueIndex;not null;type:char(64)" json:"-"`
+	FamilyID   string     `gorm:"not null;index;type:varchar(64)" json:"family_id"` // Shared by every token rotated from the same login
+	ExpiresAt  time.Time  `gorm:"not null;index" json:"expires_at"`
+	RevokedAt  *time.Time `gorm:"index" json:"revoked_at"`
+	ReplacedBy *uint      `json:"replaced_by"`
+
+	// Relationships
+	User *User `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"user,omitempty"`
+}
+
+// IsActive reports whether the token can still be exchanged
+func (rt *RefreshToken) IsActive(now time.Time) bool {
+	return rt.RevokedAt == nil && now.Before(rt.ExpiresAt)
+}
+
+// RevokedToken records an access token ID (jti) invalidated by logout.
+// Entries only need to live until the access token itself would have expired.
+type RevokedToken struct {
+	gorm.Model
+	JTI       string    `gorm:"uniqueIndex;not null;type:varchar(64)" json:"jti"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+}
@@ -1,16 +1,31 @@
 package models
 
 import (
+	"errors"
+
 	"github.com/Wilson1510/klampis-pim-go/pkg/utils"
 	"gorm.io/gorm"
 )
 
+// ErrCategoryCycle is returned when a category would become its own ancestor
+var ErrCategoryCycle = errors.New("category cannot be its own ancestor")
+
 type Category struct {
 	Base
 	Name        string `gorm:"not null;type:varchar(100)" json:"name"`
-	Slug        string `gorm:"uniqueIndex;not null;type:varchar(120)" json:"slug"`
+	Slug        string `gorm:"uniqueIndex;not null;type:varchar(120);index:idx_categories_slug_pattern,expression:slug text_pattern_ops" json:"slug"`
+	SlugPinned  bool   `gorm:"not null;default:false" json:"slug_pinned"` // Keeps the slug when the name changes
 	Description string `gorm:"type:text" json:"description"`
 	ParentID    *uint  `gorm:"index" json:"parent_id"`
+	// Path lists the IDs from the root down to this category, e.g. "/1/4/9/".
+	// It is maintained by the hooks below; see category_path.go.
+	Path string `gorm:"type:text;not null;default:'';index:idx_categories_path,expression:path text_pattern_ops" json:"path"`
+	// FullPath joins the slugs from the root down to this category, e.g.
+	// "electronics/laptops/gaming", and is maintained together with Path
+	FullPath string `gorm:"type:text;not null;default:'';index" json:"full_path"`
+
+	// stored holds the paths saved before the current update, used to rewrite descendants
+	stored categoryPaths
 
 	// Self-referencing relationships
 	Parent   *Category  `gorm:"foreignKey:ParentID" json:"parent,omitempty"`
@@ -22,12 +37,64 @@ type Category struct {
 
 // BeforeCreate is a GORM hook that runs before creating a record
 func (c *Category) BeforeCreate(tx *gorm.DB) error {
+	if err := c.validateParent(tx); err != nil {
+		return err
+	}
 	return utils.GenerateModelSlug(c, tx)
 }
 
+// AfterCreate is a GORM hook that runs after creating a record
+func (c *Category) AfterCreate(tx *gorm.DB) error {
+	return c.syncPath(tx)
+}
+
 // BeforeUpdate is a GORM hook that runs before updating a record
 func (c *Category) BeforeUpdate(tx *gorm.DB) error {
-	return utils.GenerateModelSlug(c, tx)
+	if err := c.loadStoredPath(tx); err != nil {
+		return err
+	}
+	return updateSlug(c, tx)
+}
+
+// AfterUpdate is a GORM hook that runs after updating a record. The parent is
+// validated after the write so that parent_id changes made with Update(...) are
+// seen too; returning an error rolls the update back.
+func (c *Category) AfterUpdate(tx *gorm.DB) error {
+	if err := c.validateParent(tx); err != nil {
+		return err
+	}
+	return c.syncPath(tx)
+}
+
+// validateParent rejects a ParentID pointing at the category itself or at one of
+// its descendants. The ancestors of the new parent are walked with a path guard,
+// so a cycle already present in the data cannot make the query loop.
+func (c *Category) validateParent(tx *gorm.DB) error {
+	if c.ParentID == nil || c.ID == 0 {
+		return nil
+	}
+	if *c.ParentID == c.ID {
+		return ErrCategoryCycle
+	}
+
+	var cycle bool
+	err := tx.Raw(`
+		WITH RECURSIVE ancestors AS (
+			SELECT id, parent_id, ARRAY[id] AS path FROM categories WHERE id = ?
+			UNION ALL
+			SELECT c.id, c.parent_id, ancestors.path || c.id
+			FROM categories c JOIN ancestors ON c.id = ancestors.parent_id
+			WHERE NOT c.id = ANY(ancestors.path)
+		)
+		SELECT EXISTS (SELECT 1 FROM ancestors WHERE id = ?)`, *c.ParentID, c.ID).
+		Scan(&cycle).Error
+	if err != nil {
+		return err
+	}
+	if cycle {
+		return ErrCategoryCycle
+	}
+	return nil
 }
 
 // SlugModel interface implementation for Category
@@ -42,6 +109,11 @@ func (c *Category) GetSlug() string {
 	return c.Slug
 }
 
+// IsSlugPinned reports whether the slug must be kept when the name changes
+func (c *Category) IsSlugPinned() bool {
+	return c.SlugPinned
+}
+
 // SetSlug sets the slug field
 func (c *Category) SetSlug(slug string) {
 	c.Slug = slug
@@ -4,6 +4,8 @@
 package models_test
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/Wilson1510/klampis-pim-go/internal/models"
@@ -308,6 +310,182 @@ func TestCategoryUpdate_Integration(t *testing.T) {
 	})
 }
 
+func TestCategoryCycle_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testUser := models.User{
+		Username: "testuser",
+		Password: "password123",
+		Name:     "Test User",
+		Role:     models.RoleUser,
+	}
+	if err := db.Create(&testUser).Error; err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	audit := models.Base{CreatedBy: testUser.ID, UpdatedBy: testUser.ID}
+
+	// Root > Child > Grandchild
+	root := models.Category{Name: "Root", Base: audit}
+	db.Create(&root)
+	child := models.Category{Name: "Child", ParentID: &root.ID, Base: audit}
+	db.Create(&child)
+	grandchild := models.Category{Name: "Grandchild", ParentID: &child.ID, Base: audit}
+	db.Create(&grandchild)
+
+	testCases := []struct {
+		name        string
+		category    models.Category
+		parentID    uint
+		expectCycle bool
+	}{
+		{name: "Parent is the category itself", category: child, parentID: child.ID, expectCycle: true},
+		{name: "Parent is a direct child", category: root, parentID: child.ID, expectCycle: true},
+		{name: "Parent is a deeper descendant", category: root, parentID: grandchild.ID, expectCycle: true},
+		{name: "Parent is a sibling branch", category: grandchild, parentID: root.ID, expectCycle: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			category := tc.category
+			category.ParentID = &tc.parentID
+
+			err := db.Save(&category).Error
+			if tc.expectCycle && !errors.Is(err, models.ErrCategoryCycle) {
+				t.Errorf("Expected ErrCategoryCycle, got %v", err)
+			}
+			if !tc.expectCycle && err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+		})
+	}
+
+	var stored models.Category
+	db.First(&stored, root.ID)
+	if stored.ParentID != nil {
+		t.Errorf("Expected root to stay at the root level, got parent %d", *stored.ParentID)
+	}
+}
+
+func TestCategoryPath_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testUser := models.User{
+		Username: "testuser",
+		Password: "password123",
+		Name:     "Test User",
+		Role:     models.RoleUser,
+	}
+	if err := db.Create(&testUser).Error; err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	audit := models.Base{CreatedBy: testUser.ID, UpdatedBy: testUser.ID}
+	reload := func(category models.Category) models.Category {
+		var stored models.Category
+		db.First(&stored, category.ID)
+		return stored
+	}
+
+	// Electronics > Computers > Laptops, Office
+	electronics := models.Category{Name: "Electronics", Base: audit}
+	db.Create(&electronics)
+	computers := models.Category{Name: "Computers", ParentID: &electronics.ID, Base: audit}
+	db.Create(&computers)
+	laptops := models.Category{Name: "Laptops", ParentID: &computers.ID, Base: audit}
+	db.Create(&laptops)
+	office := models.Category{Name: "Office", Base: audit}
+	db.Create(&office)
+
+	t.Run("Paths are set on create", func(t *testing.T) {
+		expected := fmt.Sprintf("/%d/%d/%d/", electronics.ID, computers.ID, laptops.ID)
+		if stored := reload(laptops); stored.Path != expected {
+			t.Errorf("Expected path '%s', got '%s'", expected, stored.Path)
+		}
+		if laptops.Path != expected || laptops.Depth() != 2 {
+			t.Errorf("Expected the created struct to carry path '%s' at depth 2, got '%s'", expected, laptops.Path)
+		}
+	})
+
+	t.Run("Ancestors and descendants", func(t *testing.T) {
+		ancestors, err := laptops.Ancestors(db)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if len(ancestors) != 2 || ancestors[0].ID != electronics.ID || ancestors[1].ID != computers.ID {
+			t.Errorf("Expected Electronics then Computers, got %+v", ancestors)
+		}
+
+		electronics = reload(electronics)
+		descendants, err := electronics.Descendants(db)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if len(descendants) != 2 || descendants[0].ID != computers.ID || descendants[1].ID != laptops.ID {
+			t.Errorf("Expected Computers then Laptops, got %+v", descendants)
+		}
+	})
+
+	t.Run("Moving a category rewrites its subtree", func(t *testing.T) {
+		computers = reload(computers)
+		computers.ParentID = &office.ID
+		if err := db.Save(&computers).Error; err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		expected := fmt.Sprintf("/%d/%d/%d/", office.ID, computers.ID, laptops.ID)
+		if stored := reload(laptops); stored.Path != expected {
+			t.Errorf("Expected path '%s', got '%s'", expected, stored.Path)
+		}
+	})
+
+	t.Run("Moving to the root level with Update", func(t *testing.T) {
+		if err := db.Model(&computers).Update("parent_id", nil).Error; err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		expected := fmt.Sprintf("/%d/%d/", computers.ID, laptops.ID)
+		if stored := reload(laptops); stored.Path != expected {
+			t.Errorf("Expected path '%s', got '%s'", expected, stored.Path)
+		}
+	})
+
+	t.Run("Full paths follow slugs", func(t *testing.T) {
+		stored := reload(laptops)
+		if stored.FullPath != "computers/laptops" {
+			t.Errorf("Expected full path 'computers/laptops', got '%s'", stored.FullPath)
+		}
+
+		computers = reload(computers)
+		computers.Name = "PCs"
+		if err := db.Save(&computers).Error; err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if computers.FullPath != "pcs" {
+			t.Errorf("Expected renamed full path 'pcs', got '%s'", computers.FullPath)
+		}
+		if stored := reload(laptops); stored.FullPath != "pcs/laptops" {
+			t.Errorf("Expected descendant full path 'pcs/laptops', got '%s'", stored.FullPath)
+		}
+	})
+
+	t.Run("Renaming keeps the path", func(t *testing.T) {
+		laptops = reload(laptops)
+		path := laptops.Path
+		laptops.Name = "Notebooks"
+		if err := db.Save(&laptops).Error; err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		stored := reload(laptops)
+		if stored.Path != path {
+			t.Errorf("Expected path '%s', got '%s'", path, stored.Path)
+		}
+		if stored.FullPath != "pcs/notebooks" {
+			t.Errorf("Expected full path 'pcs/notebooks', got '%s'", stored.FullPath)
+		}
+	})
+}
+
 func TestCategoryDelete_Integration(t *testing.T) {
 	db := testutil.SetupTestDB(t)
 	defer testutil.CleanupTestDB(t, db)
@@ -0,0 +1,173 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+// ErrCategoryPathMissing is returned by the path helpers for a category whose
+// path has not been computed yet (see database.BackfillCategoryPaths)
+var ErrCategoryPathMissing = errors.New("category path has not been computed")
+
+// categoryPath builds the path of a category below a parent path
+func categoryPath(parentPath string, id uint) string {
+	if parentPath == "" {
+		parentPath = "/"
+	}
+	return parentPath + strconv.FormatUint(uint64(id), 10) + "/"
+}
+
+// categoryPaths holds the two materialized paths of a category
+type categoryPaths struct {
+	Path     string
+	FullPath string
+}
+
+// loadPaths reads the paths currently saved for a category
+func loadPaths(tx *gorm.DB, id uint) (categoryPaths, error) {
+	var paths categoryPaths
+	err := tx.Unscoped().Model(&Category{}).Select("path", "full_path").Where("id = ?", id).Limit(1).Scan(&paths).Error
+	return paths, err
+}
+
+// loadStoredPath remembers the paths saved in the database before an update
+func (c *Category) loadStoredPath(tx *gorm.DB) error {
+	if c.ID == 0 {
+		return nil
+	}
+
+	stored, err := loadPaths(tx, c.ID)
+	if err != nil {
+		return err
+	}
+	c.stored = stored
+	return nil
+}
+
+// syncPath recomputes the paths of the category from its parent and, when the
+// category moved or its slug changed, rewrites the path prefixes of every
+// descendant. The full path is built from the saved slug rather than the struct,
+// so it always matches the row. Bulk updates of parent_id or slug without a
+// loaded category are not tracked.
+func (c *Category) syncPath(tx *gorm.DB) error {
+	if c.ID == 0 {
+		return nil
+	}
+
+	path, slugPrefix := categoryPath("", c.ID), ""
+	if c.ParentID != nil {
+		parent, err := loadPaths(tx, *c.ParentID)
+		if err != nil {
+			return err
+		}
+		// A parent without paths is left for the backfill to fix, together with this category
+		path = ""
+		if parent.Path != "" && parent.FullPath != "" {
+			path, slugPrefix = categoryPath(parent.Path, c.ID), parent.FullPath+"/"
+		}
+	}
+
+	fullPath := gorm.Expr("''")
+	if path != "" {
+		fullPath = gorm.Expr("? || slug", slugPrefix)
+	}
+	var saved categoryPaths
+	err := tx.Raw("UPDATE categories SET path = ?, full_path = ? WHERE id = ? RETURNING path, full_path",
+		path, fullPath, c.ID).Scan(&saved).Error
+	if err != nil {
+		return err
+	}
+
+	if c.stored.Path != "" && saved.Path != "" && c.stored.Path != saved.Path {
+		err = tx.Exec("UPDATE categories SET path = ? || substr(path, ?) WHERE path LIKE ? AND id <> ?",
+			saved.Path, len(c.stored.Path)+1, c.stored.Path+"%", c.ID).Error
+		if err != nil {
+			return err
+		}
+	}
+	if c.stored.FullPath != "" && saved.FullPath != "" && c.stored.FullPath != saved.FullPath {
+		// substr counts characters, so the old prefix is measured in runes
+		err = tx.Exec("UPDATE categories SET full_path = ? || substr(full_path, ?) WHERE path LIKE ? AND id <> ?",
+			saved.FullPath, utf8.RuneCountInString(c.stored.FullPath)+1, saved.Path+"%", c.ID).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	c.Path, c.FullPath = saved.Path, saved.FullPath
+	c.stored = saved
+	return nil
+}
+
+// AncestorIDs returns the IDs of the ancestors of the category, root first
+func (c *Category) AncestorIDs() []uint {
+	parts := strings.Split(strings.Trim(c.Path, "/"), "/")
+	if c.Path == "" || len(parts) < 2 {
+		return nil
+	}
+
+	ids := make([]uint, 0, len(parts)-1)
+	for _, part := range parts[:len(parts)-1] {
+		id, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return nil
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids
+}
+
+// Depth returns how many levels the category is below the root level (0 for a
+// root category), or -1 when its path has not been computed
+func (c *Category) Depth() int {
+	if c.Path == "" {
+		return -1
+	}
+	return strings.Count(c.Path, "/") - 2
+}
+
+// Ancestors loads the ancestors of the category, root first
+func (c *Category) Ancestors(tx *gorm.DB) ([]Category, error) {
+	if c.Path == "" {
+		return nil, ErrCategoryPathMissing
+	}
+
+	ids := c.AncestorIDs()
+	if len(ids) == 0 {
+		return []Category{}, nil
+	}
+
+	var ancestors []Category
+	if err := tx.Where("id IN ?", ids).Order("length(path)").Find(&ancestors).Error; err != nil {
+		return nil, err
+	}
+	return ancestors, nil
+}
+
+// Descendants loads every category below this one, ordered by path so that
+// each category comes after its parent
+func (c *Category) Descendants(tx *gorm.DB) ([]Category, error) {
+	if c.Path == "" {
+		return nil, ErrCategoryPathMissing
+	}
+
+	var descendants []Category
+	err := tx.Where("path LIKE ? AND id <> ?", c.Path+"%", c.ID).Order("path").Find(&descendants).Error
+	if err != nil {
+		return nil, err
+	}
+	return descendants, nil
+}
+
+// SubtreeIDs returns a subquery selecting the ID of the category and of all its
+// descendants, e.g. for tx.Where("category_id IN (?)", category.SubtreeIDs(tx))
+func (c *Category) SubtreeIDs(tx *gorm.DB) (*gorm.DB, error) {
+	if c.Path == "" {
+		return nil, ErrCategoryPathMissing
+	}
+	return tx.Model(&Category{}).Select("id").Where("path LIKE ?", c.Path+"%"), nil
+}
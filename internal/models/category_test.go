@@ -1,6 +1,8 @@
 package models
 
 import (
+	"errors"
+	"reflect"
 	"testing"
 )
 
@@ -36,3 +38,62 @@ func TestCategorySlugModelInterface(t *testing.T) {
 		t.Errorf("Expected slug 'new-category-slug' after SetSlug, got '%s'", category.GetSlug())
 	}
 }
+
+// TestCategoryValidateParentSelf tests that a category cannot be its own parent
+func TestCategoryValidateParentSelf(t *testing.T) {
+	category := Category{Name: "Test Category"}
+	category.ID = 1
+	category.ParentID = &category.ID
+
+	// The self reference is rejected before any query runs
+	if err := category.validateParent(nil); !errors.Is(err, ErrCategoryCycle) {
+		t.Errorf("Expected ErrCategoryCycle, got %v", err)
+	}
+
+	// New categories and root categories need no check
+	newCategory := Category{Name: "New Category", ParentID: &category.ID}
+	if err := newCategory.validateParent(nil); err != nil {
+		t.Errorf("Expected no error for a new category, got %v", err)
+	}
+	category.ParentID = nil
+	if err := category.validateParent(nil); err != nil {
+		t.Errorf("Expected no error for a root category, got %v", err)
+	}
+}
+
+// TestCategoryPathHelpers tests the helpers reading the materialized path
+func TestCategoryPathHelpers(t *testing.T) {
+	testCases := []struct {
+		name                string
+		path                string
+		expectedDepth       int
+		expectedAncestorIDs []uint
+	}{
+		{name: "Root category", path: "/1/", expectedDepth: 0, expectedAncestorIDs: nil},
+		{name: "Nested category", path: "/1/12/7/", expectedDepth: 2, expectedAncestorIDs: []uint{1, 12}},
+		{name: "Path not computed", path: "", expectedDepth: -1, expectedAncestorIDs: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			category := Category{Path: tc.path}
+
+			if depth := category.Depth(); depth != tc.expectedDepth {
+				t.Errorf("Expected depth %d, got %d", tc.expectedDepth, depth)
+			}
+			if ids := category.AncestorIDs(); !reflect.DeepEqual(ids, tc.expectedAncestorIDs) {
+				t.Errorf("Expected ancestor IDs %v, got %v", tc.expectedAncestorIDs, ids)
+			}
+		})
+	}
+
+	if path := categoryPath("/1/12/", 7); path != "/1/12/7/" {
+		t.Errorf("Expected path '/1/12/7/', got '%s'", path)
+	}
+	if path := categoryPath("", 3); path != "/3/" {
+		t.Errorf("Expected root path '/3/', got '%s'", path)
+	}
+	if _, err := (&Category{}).Descendants(nil); !errors.Is(err, ErrCategoryPathMissing) {
+		t.Errorf("Expected ErrCategoryPathMissing, got %v", err)
+	}
+}
@@ -8,7 +8,8 @@ import (
 type Product struct {
 	Base
 	Name        string `gorm:"not null;type:varchar(150)" json:"name"`
-	Slug        string `gorm:"uniqueIndex;not null;type:varchar(170)" json:"slug"`
+	Slug        string `gorm:"uniqueIndex;not null;type:varchar(170);index:idx_products_slug_pattern,expression:slug text_pattern_ops" json:"slug"`
+	SlugPinned  bool   `gorm:"not null;default:false" json:"slug_pinned"` // Keeps the slug when the name changes
 	Description string `gorm:"type:text" json:"description"`
 	CategoryID  uint   `gorm:"not null;index" json:"category_id"`
 
@@ -29,7 +30,7 @@ func (p *Product) BeforeCreate(tx *gorm.DB) error {
 
 // BeforeUpdate is a GORM hook that runs before updating a record
 func (p *Product) BeforeUpdate(tx *gorm.DB) error {
-	return utils.GenerateModelSlug(p, tx)
+	return updateSlug(p, tx)
 }
 
 // SlugModel interface implementation for Product
@@ -44,6 +45,11 @@ func (p *Product) GetSlug() string {
 	return p.Slug
 }
 
+// IsSlugPinned reports whether the slug must be kept when the name changes
+func (p *Product) IsSlugPinned() bool {
+	return p.SlugPinned
+}
+
 // SetSlug sets the slug field
 func (p *Product) SetSlug(slug string) {
 	p.Slug = slug
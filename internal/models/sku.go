@@ -8,7 +8,8 @@ import (
 type Sku struct {
 	Base
 	Name        string  `gorm:"not null;type:varchar(200)" json:"name"`
-	Slug        string  `gorm:"uniqueIndex;not null;type:varchar(220)" json:"slug"`
+	Slug        string  `gorm:"uniqueIndex;not null;type:varchar(220);index:idx_skus_slug_pattern,expression:slug text_pattern_ops" json:"slug"`
+	SlugPinned  bool    `gorm:"not null;default:false" json:"slug_pinned"` // Keeps the slug when the name changes
 	Description string  `gorm:"type:text" json:"description"`
 	SkuNumber   string  `gorm:"uniqueIndex;not null;type:varchar(50)" json:"sku_number"`
 	Price       float64 `gorm:"not null;type:decimal(15,2)" json:"price"`
@@ -31,7 +32,7 @@ func (s *Sku) BeforeCreate(tx *gorm.DB) error {
 
 // BeforeUpdate is a GORM hook that runs before updating a record
 func (s *Sku) BeforeUpdate(tx *gorm.DB) error {
-	return utils.GenerateModelSlug(s, tx)
+	return updateSlug(s, tx)
 }
 
 // SlugModel interface implementation for Sku
@@ -46,6 +47,11 @@ func (s *Sku) GetSlug() string {
 	return s.Slug
 }
 
+// IsSlugPinned reports whether the slug must be kept when the name changes
+func (s *Sku) IsSlugPinned() bool {
+	return s.SlugPinned
+}
+
 // SetSlug sets the slug field
 func (s *Sku) SetSlug(slug string) {
 	s.Slug = slug
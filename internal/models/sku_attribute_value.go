@@ -1,18 +1,38 @@
 package models
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Wilson1510/klampis-pim-go/pkg/utils"
 	"gorm.io/gorm"
 )
 
+// SkuAttributeValueIndex is the unique index that allows one value per SKU and
+// attribute at every position
+const SkuAttributeValueIndex = "idx_sku_attr_position"
+
 type SkuAttributeValue struct {
 	gorm.Model
-	SkuID       uint   `gorm:"not null;index:idx_sku_attr" json:"sku_id"`
-	AttributeID uint   `gorm:"not null;index:idx_sku_attr" json:"attribute_id"`
+	SkuID       uint   `gorm:"not null;index:idx_sku_attr;uniqueIndex:idx_sku_attr_position,priority:1,where:deleted_at IS NULL" json:"sku_id"`
+	AttributeID uint   `gorm:"not null;index:idx_sku_attr;uniqueIndex:idx_sku_attr_position,priority:2" json:"attribute_id"`
 	Value       string `gorm:"type:text;not null" json:"value"`
-	CreatedBy   uint   `gorm:"" json:"created_by"`        // Optional: untuk audit trail
-	UpdatedBy   uint   `gorm:"" json:"updated_by"`        // Optional: untuk audit trail
-	Sequence    int    `gorm:"default:0" json:"sequence"` // Untuk ordering attributes display
+	// Position orders the values of a multi-valued attribute; a single-valued attribute only has position 0
+	Position int `gorm:"not null;default:0;uniqueIndex:idx_sku_attr_position,priority:3" json:"position"`
+	// PossiblyRounded flags a number stored before numbers were formatted losslessly
+	// that may have been rounded to two decimal places; writing a new value clears it
+	PossiblyRounded *bool `gorm:"" json:"possibly_rounded,omitempty"`
+	// BaseValue is a number in the base unit of its attribute's dimension, so that
+	// values compare across units; nil for other data types
+	BaseValue *float64 `gorm:"index" json:"base_value,omitempty"`
+	CreatedBy uint     `gorm:"" json:"created_by"`        // Stamped by database.AuditPlugin
+	UpdatedBy uint     `gorm:"" json:"updated_by"`        // Stamped by database.AuditPlugin
+	Sequence  int      `gorm:"default:0" json:"sequence"` // Untuk ordering attributes display
+
+	// DisplayUnit is the unit numbers are displayed in instead of the attribute's unit; not stored
+	DisplayUnit *Unit `gorm:"-" json:"-"`
 
 	// Relationships
 	Sku           *Sku       `gorm:"foreignKey:SkuID" json:"sku,omitempty"`
@@ -31,38 +51,95 @@ func (sav *SkuAttributeValue) BeforeUpdate(tx *gorm.DB) error {
 	return sav.validateValue(tx)
 }
 
-// validateValue validates the value against the attribute's data type
+// validateValue validates the value against the attribute's data type and rules.
+// An option is stored by its code, whichever spelling was given, a date in its
+// ISO form and a number in the unit of the attribute.
 func (sav *SkuAttributeValue) validateValue(tx *gorm.DB) error {
 	// Fetch the attribute to get its data type
-	var attribute Attribute
-	if err := tx.First(&attribute, sav.AttributeID).Error; err != nil {
-		return fmt.Errorf("attribute not found: %w", err)
+	attribute, err := sav.loadAttribute(tx)
+	if err != nil {
+		return err
+	}
+
+	if sav.Position < 0 {
+		return fmt.Errorf("invalid position %d for attribute '%s'", sav.Position, attribute.Name)
+	}
+	if sav.Position > 0 && !attribute.MultiValued {
+		return fmt.Errorf("attribute '%s' takes a single value", attribute.Name)
 	}
 
 	// Validate value according to attribute's data type
-	if err := attribute.ValidateValue(sav.Value); err != nil {
+	parsed, err := attribute.ParseValue(sav.Value)
+	if err != nil {
 		return fmt.Errorf("invalid value for attribute '%s' (type: %s): %w",
 			attribute.Name, attribute.DataType, err)
 	}
+	if err := attribute.CheckRules(parsed); err != nil {
+		return fmt.Errorf("invalid value for attribute '%s': %w", attribute.Name, err)
+	}
+	switch v := parsed.(type) {
+	case AttributeOption:
+		sav.Value = v.Code
+	case time.Time:
+		sav.Value = v.Format(utils.DateLayout)
+	case float64:
+		// A number entered in another unit, e.g. 1 TB for a GB attribute, is stored converted
+		if _, err := strconv.ParseFloat(sav.Value, 64); err != nil {
+			sav.Value = formatNumber(v, 64)
+		}
+	}
+	sav.BaseValue = attribute.BaseValue(sav.Value)
+
+	return sav.clearRoundedFlag(tx)
+}
+
+// clearRoundedFlag clears PossiblyRounded when the value is new or changed.
+// Saving a flagged value as it is, e.g. to reorder it, keeps it flagged.
+func (sav *SkuAttributeValue) clearRoundedFlag(tx *gorm.DB) error {
+	if sav.ID != 0 {
+		var stored SkuAttributeValue
+		err := tx.Unscoped().Select("value", "possibly_rounded").First(&stored, sav.ID).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err == nil && stored.Value == sav.Value {
+			sav.PossiblyRounded = stored.PossiblyRounded
+			return nil
+		}
+	}
 
+	notRounded := false
+	sav.PossiblyRounded = &notRounded
 	return nil
 }
 
-// GetParsedValue returns the value parsed according to the attribute's data type
-func (sav *SkuAttributeValue) GetParsedValue(tx *gorm.DB) (interface{}, error) {
+// loadAttribute fetches the attribute of the value together with its options
+func (sav *SkuAttributeValue) loadAttribute(tx *gorm.DB) (*Attribute, error) {
 	var attribute Attribute
 	if err := tx.First(&attribute, sav.AttributeID).Error; err != nil {
 		return nil, fmt.Errorf("attribute not found: %w", err)
 	}
+	if err := attribute.LoadOptions(tx); err != nil {
+		return nil, err
+	}
+	return &attribute, nil
+}
+
+// GetParsedValue returns the value parsed according to the attribute's data type
+func (sav *SkuAttributeValue) GetParsedValue(tx *gorm.DB) (interface{}, error) {
+	attribute, err := sav.loadAttribute(tx)
+	if err != nil {
+		return nil, err
+	}
 
 	return attribute.ParseValue(sav.Value)
 }
 
 // SetValue sets the value with automatic type conversion
 func (sav *SkuAttributeValue) SetValue(value interface{}, tx *gorm.DB) error {
-	var attribute Attribute
-	if err := tx.First(&attribute, sav.AttributeID).Error; err != nil {
-		return fmt.Errorf("attribute not found: %w", err)
+	attribute, err := sav.loadAttribute(tx)
+	if err != nil {
+		return err
 	}
 
 	valueStr, err := attribute.FormatValue(value)
@@ -74,7 +151,8 @@ func (sav *SkuAttributeValue) SetValue(value interface{}, tx *gorm.DB) error {
 	return nil
 }
 
-// GetDisplayValue returns a formatted display value with UOM if applicable
+// GetDisplayValue returns a formatted display value with UOM if applicable.
+// Options are displayed by their label, and numbers in the DisplayUnit if set.
 func (sav *SkuAttributeValue) GetDisplayValue(tx *gorm.DB) (string, error) {
 	var attribute Attribute
 	// Check if Attribute is already preloaded
@@ -86,13 +164,14 @@ func (sav *SkuAttributeValue) GetDisplayValue(tx *gorm.DB) (string, error) {
 			return "", fmt.Errorf("attribute not found: %w", err)
 		}
 	}
-
-	// Format display value with UOM if available
-	if attribute.UOM != "" {
-		return fmt.Sprintf("%s %s", sav.Value, attribute.UOM), nil
+	if err := attribute.LoadOptions(tx); err != nil {
+		return "", err
 	}
 
-	return sav.Value, nil
+	if sav.DisplayUnit != nil {
+		return attribute.DisplayValuesIn(sav.DisplayUnit, sav.Value), nil
+	}
+	return attribute.DisplayValues(sav.Value), nil
 }
 
 // TableName specifies the table name for SkuAttributeValue
@@ -119,7 +198,7 @@ func (SkuAttributeValue) TableName() string {
 // }
 //
 // // Get parsed value
-// parsedValue, _ := skuAttrValue.GetParsedValue(db) // Returns: int64(16)
+// parsedValue, _ := skuAttrValue.GetParsedValue(db) // Returns: float64(16)
 //
 // // Get display value
 // displayValue, _ := skuAttrValue.GetDisplayValue(db) // Returns: "16 GB"
@@ -724,7 +724,11 @@ func TestSkuAttributeValueHelperMethods_Integration(t *testing.T) {
 			CreatedBy:   testUser.ID,
 			UpdatedBy:   testUser.ID,
 		}
-		db.Create(&attrValue)
+		if err := db.Create(&attrValue).Error; err != nil {
+			t.Fatalf("Failed to create attribute value: %v", err)
+		}
+		// The SKU holds one RAM value at a time, so free it for the next subtest
+		defer db.Delete(&attrValue)
 
 		parsed, err := attrValue.GetParsedValue(db)
 		if err != nil {
@@ -743,7 +747,11 @@ func TestSkuAttributeValueHelperMethods_Integration(t *testing.T) {
 			CreatedBy:   testUser.ID,
 			UpdatedBy:   testUser.ID,
 		}
-		db.Create(&attrValue)
+		if err := db.Create(&attrValue).Error; err != nil {
+			t.Fatalf("Failed to create attribute value: %v", err)
+		}
+		// The SKU holds one RAM value at a time, so free it for the next subtest
+		defer db.Delete(&attrValue)
 
 		display, err := attrValue.GetDisplayValue(db)
 		if err != nil {
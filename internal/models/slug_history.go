@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"github.com/Wilson1510/klampis-pim-go/pkg/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SlugHistory records a slug that a category, product or SKU used before it was
+// renamed, so that published URLs can be redirected to the current slug.
+// EntityType is the table name of the renamed record.
+type SlugHistory struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	EntityType string    `gorm:"not null;type:varchar(20);uniqueIndex:idx_slug_histories_entity_slug,priority:1" json:"entity_type"`
+	Slug       string    `gorm:"not null;type:varchar(220);uniqueIndex:idx_slug_histories_entity_slug,priority:2" json:"slug"`
+	EntityID   uint      `gorm:"not null;index" json:"entity_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for SlugHistory
+func (SlugHistory) TableName() string {
+	return "slug_histories"
+}
+
+// FindSlugHistory returns the record that used slug last among the given entity type
+func FindSlugHistory(tx *gorm.DB, entityType, slug string) (*SlugHistory, error) {
+	var history SlugHistory
+	if err := tx.Where("entity_type = ? AND slug = ?", entityType, slug).First(&history).Error; err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
+
+// updateSlug regenerates the slug of a renamed model and records the slug it
+// replaces. The previous slug is read from the database, since the struct may
+// not have been loaded from it.
+func updateSlug(model utils.SlugModel, tx *gorm.DB) error {
+	if err := utils.GenerateModelSlug(model, tx); err != nil {
+		return err
+	}
+	if model.GetID() == 0 {
+		return nil
+	}
+
+	var stored []string
+	err := tx.Unscoped().Table(model.GetTableName()).Where("id = ?", model.GetID()).Limit(1).Pluck("slug", &stored).Error
+	if err != nil {
+		return err
+	}
+	if len(stored) == 0 || stored[0] == "" || stored[0] == model.GetSlug() {
+		return nil
+	}
+
+	return recordSlugChange(tx, model.GetTableName(), model.GetID(), stored[0], model.GetSlug())
+}
+
+// recordSlugChange stores oldSlug as a previous slug of the record. When several
+// records used the same slug over time, the latest one keeps the redirect.
+func recordSlugChange(tx *gorm.DB, entityType string, id uint, oldSlug, newSlug string) error {
+	// A record renamed back to an earlier slug no longer needs that redirect
+	err := tx.Where("entity_type = ? AND slug = ?", entityType, newSlug).Delete(&SlugHistory{}).Error
+	if err != nil {
+		return err
+	}
+
+	history := SlugHistory{EntityType: entityType, Slug: oldSlug, EntityID: id}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "entity_type"}, {Name: "slug"}},
+		DoUpdates: clause.AssignmentColumns([]string{"entity_id", "created_at"}),
+	}).Create(&history).Error
+}
@@ -0,0 +1,91 @@
+//go:build integration
+// +build integration
+
+package models_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/internal/testutil"
+	"gorm.io/gorm"
+)
+
+func TestSlugHistory_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testUser := models.User{
+		Username: "testuser",
+		Password: "password123",
+		Name:     "Test User",
+		Role:     models.RoleUser,
+	}
+	if err := db.Create(&testUser).Error; err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	audit := models.Base{CreatedBy: testUser.ID, UpdatedBy: testUser.ID}
+
+	category := models.Category{Name: "Computers", Base: audit}
+	db.Create(&category)
+	product := models.Product{Name: "Gaming Laptop", CategoryID: category.ID, Base: audit}
+	db.Create(&product)
+
+	t.Run("Rename records the previous slug", func(t *testing.T) {
+		product.Name = "Gaming Notebook"
+		if err := db.Save(&product).Error; err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		history, err := models.FindSlugHistory(db, "products", "gaming-laptop")
+		if err != nil {
+			t.Fatalf("Expected history for 'gaming-laptop', got %v", err)
+		}
+		if history.EntityID != product.ID {
+			t.Errorf("Expected history to point to product %d, got %d", product.ID, history.EntityID)
+		}
+	})
+
+	t.Run("Saving without a rename records nothing", func(t *testing.T) {
+		product.Description = "Updated description"
+		db.Save(&product)
+
+		var count int64
+		db.Model(&models.SlugHistory{}).Where("entity_type = ? AND entity_id = ?", "products", product.ID).Count(&count)
+		if count != 1 {
+			t.Errorf("Expected 1 history entry, got %d", count)
+		}
+	})
+
+	t.Run("Renaming back drops the redirect of the reused slug", func(t *testing.T) {
+		product.Name = "Gaming Laptop"
+		if err := db.Save(&product).Error; err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		if _, err := models.FindSlugHistory(db, "products", "gaming-laptop"); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("Expected no history for the current slug, got %v", err)
+		}
+		if _, err := models.FindSlugHistory(db, "products", "gaming-notebook"); err != nil {
+			t.Errorf("Expected history for 'gaming-notebook', got %v", err)
+		}
+	})
+
+	t.Run("Pinned slug survives a rename", func(t *testing.T) {
+		category.SlugPinned = true
+		category.Name = "Desktop Computers"
+		if err := db.Save(&category).Error; err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		var stored models.Category
+		db.First(&stored, category.ID)
+		if stored.Slug != "computers" {
+			t.Errorf("Expected pinned slug 'computers', got '%s'", stored.Slug)
+		}
+		if _, err := models.FindSlugHistory(db, "categories", "computers"); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("Expected no history for a pinned slug, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,17 @@
+package models
+
+import "github.com/Wilson1510/klampis-pim-go/pkg/utils"
+
+// SlugSequence holds the last numeric suffix handed out for a base slug of a
+// table, so that the next record with the same name gets its slug without
+// reading the slugs already in use. It is maintained by utils.GenerateModelSlug.
+type SlugSequence struct {
+	SlugTable  string `gorm:"primaryKey;type:varchar(64)" json:"slug_table"`
+	Base       string `gorm:"primaryKey;type:varchar(220)" json:"base"`
+	LastSuffix int64  `gorm:"not null" json:"last_suffix"`
+}
+
+// TableName specifies the table name for SlugSequence
+func (SlugSequence) TableName() string {
+	return utils.SlugSequenceTable
+}
@@ -0,0 +1,116 @@
+//go:build integration
+// +build integration
+
+package models_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/internal/service"
+	"github.com/Wilson1510/klampis-pim-go/internal/testutil"
+	"github.com/Wilson1510/klampis-pim-go/pkg/utils"
+)
+
+func TestSlugSequence_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testUser := models.User{
+		Username: "testuser",
+		Password: "password123",
+		Name:     "Test User",
+		Role:     models.RoleUser,
+	}
+	if err := db.Create(&testUser).Error; err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	audit := models.Base{CreatedBy: testUser.ID, UpdatedBy: testUser.ID}
+
+	category := models.Category{Name: "Computers", Base: audit}
+	db.Create(&category)
+
+	t.Run("Batch insert of one name gets distinct slugs", func(t *testing.T) {
+		const total = 2000
+		products := make([]models.Product, total)
+		for i := range products {
+			products[i] = models.Product{Name: "Office Chair", CategoryID: category.ID, Base: audit}
+		}
+		if err := db.CreateInBatches(&products, 500).Error; err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		var distinct int64
+		db.Model(&models.Product{}).Where("name = ?", "Office Chair").Distinct("slug").Count(&distinct)
+		if distinct != total {
+			t.Errorf("Expected %d distinct slugs, got %d", total, distinct)
+		}
+		if products[0].Slug != "office-chair" || products[total-1].Slug != fmt.Sprintf("office-chair-%d", total-1) {
+			t.Errorf("Expected slugs office-chair..office-chair-%d, got %s..%s", total-1, products[0].Slug, products[total-1].Slug)
+		}
+	})
+
+	t.Run("Sequence continues after slugs saved before it", func(t *testing.T) {
+		for _, slug := range []string{"desk-lamp", "desk-lamp-7", "desk-lamp-shade"} {
+			legacy := models.Product{Name: "Legacy " + slug, CategoryID: category.ID, Base: audit}
+			db.Create(&legacy)
+			db.Model(&legacy).UpdateColumn("slug", slug)
+		}
+
+		product := models.Product{Name: "Desk Lamp", CategoryID: category.ID, Base: audit}
+		if err := db.Create(&product).Error; err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if product.Slug != "desk-lamp-8" {
+			t.Errorf("Expected slug 'desk-lamp-8', got '%s'", product.Slug)
+		}
+	})
+
+	t.Run("Slugs of other names are skipped", func(t *testing.T) {
+		first := models.Product{Name: "Monitor Arm", CategoryID: category.ID, Base: audit}
+		db.Create(&first)
+		other := models.Product{Name: "Monitor Arm 1", CategoryID: category.ID, Base: audit}
+		db.Create(&other)
+
+		product := models.Product{Name: "Monitor Arm", CategoryID: category.ID, Base: audit}
+		if err := db.Create(&product).Error; err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if product.Slug != "monitor-arm-2" {
+			t.Errorf("Expected slug 'monitor-arm-2', got '%s'", product.Slug)
+		}
+	})
+
+	t.Run("Concurrent inserts of one name all succeed", func(t *testing.T) {
+		const workers, perWorker = 8, 25
+		var wg sync.WaitGroup
+		errs := make(chan error, workers*perWorker)
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < perWorker; i++ {
+					product := models.Product{Name: "Standing Desk", CategoryID: category.ID, Base: audit}
+					errs <- utils.RetryOnSlugConflict(service.IsSlugConflict, func() error {
+						return db.Create(&product).Error
+					})
+				}
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			if err != nil {
+				t.Fatalf("Expected no error but got: %v", err)
+			}
+		}
+		var count int64
+		db.Model(&models.Product{}).Where("name = ?", "Standing Desk").Count(&count)
+		if count != workers*perWorker {
+			t.Errorf("Expected %d products, got %d", workers*perWorker, count)
+		}
+	})
+}
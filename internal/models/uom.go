@@ -0,0 +1,313 @@
+package models
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Dimension groups units that measure the same quantity and convert into each other
+type Dimension string
+
+const (
+	DimensionLength    Dimension = "length"
+	DimensionMass      Dimension = "mass"
+	DimensionStorage   Dimension = "storage"
+	DimensionFrequency Dimension = "frequency"
+	DimensionDuration  Dimension = "duration"
+)
+
+// Unit is a unit of measurement of the catalog
+type Unit struct {
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Dimension Dimension `json:"dimension"`
+	// Factor is the number of base units in one unit, as an exact decimal
+	Factor string `json:"factor"`
+	// Aliases are other spellings accepted for the unit, e.g. inch for in
+	Aliases []string `json:"aliases,omitempty"`
+
+	factor *big.Rat
+}
+
+// unitCatalog lists the units by dimension, the base unit of each dimension first.
+// Storage units follow the SI (1 GB = 1000 MB); the binary units are KiB, MiB, ...
+var unitCatalog = []Unit{
+	{Code: "m", Name: "metre", Dimension: DimensionLength, Factor: "1", Aliases: []string{"meter", "meters", "metres"}},
+	{Code: "mm", Name: "millimetre", Dimension: DimensionLength, Factor: "0.001"},
+	{Code: "cm", Name: "centimetre", Dimension: DimensionLength, Factor: "0.01"},
+	{Code: "km", Name: "kilometre", Dimension: DimensionLength, Factor: "1000"},
+	{Code: "in", Name: "inch", Dimension: DimensionLength, Factor: "0.0254", Aliases: []string{"inch", "inches", `"`}},
+	{Code: "ft", Name: "foot", Dimension: DimensionLength, Factor: "0.3048", Aliases: []string{"foot", "feet"}},
+	{Code: "yd", Name: "yard", Dimension: DimensionLength, Factor: "0.9144", Aliases: []string{"yard", "yards"}},
+	{Code: "mi", Name: "mile", Dimension: DimensionLength, Factor: "1609.344", Aliases: []string{"mile", "miles"}},
+
+	{Code: "kg", Name: "kilogram", Dimension: DimensionMass, Factor: "1", Aliases: []string{"kilogram", "kilograms"}},
+	{Code: "mg", Name: "milligram", Dimension: DimensionMass, Factor: "0.000001"},
+	{Code: "g", Name: "gram", Dimension: DimensionMass, Factor: "0.001", Aliases: []string{"gram", "grams"}},
+	{Code: "t", Name: "tonne", Dimension: DimensionMass, Factor: "1000", Aliases: []string{"tonne", "tonnes"}},
+	{Code: "oz", Name: "ounce", Dimension: DimensionMass, Factor: "0.028349523125", Aliases: []string{"ounce", "ounces"}},
+	{Code: "lb", Name: "pound", Dimension: DimensionMass, Factor: "0.45359237", Aliases: []string{"lbs", "pound", "pounds"}},
+
+	{Code: "B", Name: "byte", Dimension: DimensionStorage, Factor: "1", Aliases: []string{"byte", "bytes"}},
+	{Code: "KB", Name: "kilobyte", Dimension: DimensionStorage, Factor: "1000"},
+	{Code: "MB", Name: "megabyte", Dimension: DimensionStorage, Factor: "1000000"},
+	{Code: "GB", Name: "gigabyte", Dimension: DimensionStorage, Factor: "1000000000"},
+	{Code: "TB", Name: "terabyte", Dimension: DimensionStorage, Factor: "1000000000000"},
+	{Code: "PB", Name: "petabyte", Dimension: DimensionStorage, Factor: "1000000000000000"},
+	{Code: "KiB", Name: "kibibyte", Dimension: DimensionStorage, Factor: "1024"},
+	{Code: "MiB", Name: "mebibyte", Dimension: DimensionStorage, Factor: "1048576"},
+	{Code: "GiB", Name: "gibibyte", Dimension: DimensionStorage, Factor: "1073741824"},
+	{Code: "TiB", Name: "tebibyte", Dimension: DimensionStorage, Factor: "1099511627776"},
+
+	{Code: "Hz", Name: "hertz", Dimension: DimensionFrequency, Factor: "1"},
+	{Code: "kHz", Name: "kilohertz", Dimension: DimensionFrequency, Factor: "1000"},
+	{Code: "MHz", Name: "megahertz", Dimension: DimensionFrequency, Factor: "1000000"},
+	{Code: "GHz", Name: "gigahertz", Dimension: DimensionFrequency, Factor: "1000000000"},
+
+	{Code: "s", Name: "second", Dimension: DimensionDuration, Factor: "1", Aliases: []string{"sec", "second", "seconds"}},
+	{Code: "ms", Name: "millisecond", Dimension: DimensionDuration, Factor: "0.001"},
+	{Code: "min", Name: "minute", Dimension: DimensionDuration, Factor: "60", Aliases: []string{"minute", "minutes"}},
+	{Code: "h", Name: "hour", Dimension: DimensionDuration, Factor: "3600", Aliases: []string{"hr", "hour", "hours"}},
+	{Code: "d", Name: "day", Dimension: DimensionDuration, Factor: "86400", Aliases: []string{"day", "days"}},
+	{Code: "wk", Name: "week", Dimension: DimensionDuration, Factor: "604800", Aliases: []string{"week", "weeks"}},
+	// Months and years are calendar averages, so that 12 months make a year
+	{Code: "mo", Name: "month", Dimension: DimensionDuration, Factor: "2629800", Aliases: []string{"month", "months"}},
+	{Code: "yr", Name: "year", Dimension: DimensionDuration, Factor: "31557600", Aliases: []string{"year", "years"}},
+}
+
+// unitsByName indexes the catalog by code and alias, and by their lowercase
+// form for lookups that do not match exactly
+var unitsByName, unitsByLowerName = indexUnits()
+
+func indexUnits() (map[string]*Unit, map[string]*Unit) {
+	byName := make(map[string]*Unit)
+	byLowerName := make(map[string]*Unit)
+	for i := range unitCatalog {
+		unit := &unitCatalog[i]
+		factor, ok := new(big.Rat).SetString(unit.Factor)
+		if !ok {
+			panic(fmt.Sprintf("invalid factor %q of unit %s", unit.Factor, unit.Code))
+		}
+		unit.factor = factor
+
+		for _, name := range append([]string{unit.Code}, unit.Aliases...) {
+			lower := strings.ToLower(name)
+			if _, exists := byName[name]; exists {
+				panic(fmt.Sprintf("unit name %q is registered twice", name))
+			}
+			if other, exists := byLowerName[lower]; exists && other != unit {
+				panic(fmt.Sprintf("unit names of %s and %s only differ in case", other.Code, unit.Code))
+			}
+			byName[name] = unit
+			byLowerName[lower] = unit
+		}
+	}
+	return byName, byLowerName
+}
+
+// Units returns the unit catalog, grouped by dimension
+func Units() []Unit {
+	return unitCatalog
+}
+
+// LookupUnit finds a unit by code or alias. An exact match wins; otherwise case
+// is ignored, so gb finds GB.
+func LookupUnit(name string) (*Unit, bool) {
+	name = strings.TrimSpace(name)
+	if unit, ok := unitsByName[name]; ok {
+		return unit, true
+	}
+	unit, ok := unitsByLowerName[strings.ToLower(name)]
+	return unit, ok
+}
+
+// BaseUnit returns the unit the other units of the dimension are normalized to
+func (u *Unit) BaseUnit() *Unit {
+	for i := range unitCatalog {
+		if unitCatalog[i].Dimension == u.Dimension {
+			return &unitCatalog[i]
+		}
+	}
+	return u
+}
+
+// Convert converts a decimal number from this unit to another unit of the same
+// dimension. The conversion is exact; only the result is rounded to a float.
+func (u *Unit) Convert(value string, to *Unit) (float64, error) {
+	if u.Dimension != to.Dimension {
+		return 0, fmt.Errorf("cannot convert %s (%s) to %s (%s)", u.Code, u.Dimension, to.Code, to.Dimension)
+	}
+	number, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok {
+		return 0, fmt.Errorf("invalid number value: %s", value)
+	}
+
+	number.Mul(number, u.factor)
+	number.Quo(number, to.factor)
+	converted, _ := number.Float64()
+	return converted, nil
+}
+
+// quantityPattern splits a number with a unit such as "1.5 TB" or 15.6"
+var quantityPattern = regexp.MustCompile(`^([-+]?(?:[0-9]+\.?[0-9]*|\.[0-9]+)(?:[eE][-+]?[0-9]+)?)\s*(\D.*)$`)
+
+// Unit returns the unit of a NUMBER attribute if its UOM is in the catalog
+func (a *Attribute) Unit() (*Unit, bool) {
+	if a.DataType != DataTypeNumber || a.UOM == "" {
+		return nil, false
+	}
+	return LookupUnit(a.UOM)
+}
+
+// parseNumber parses a NUMBER value. A value with a unit, such as "1 TB", is
+// converted to the unit of the attribute, giving 1000 for a GB attribute.
+func (a *Attribute) parseNumber(valueStr string) (float64, error) {
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err == nil {
+		return value, nil
+	}
+
+	match := quantityPattern.FindStringSubmatch(strings.TrimSpace(valueStr))
+	if match == nil {
+		return 0, err
+	}
+	from, ok := LookupUnit(match[2])
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q", strings.TrimSpace(match[2]))
+	}
+	to, ok := a.Unit()
+	if !ok {
+		return 0, fmt.Errorf("cannot convert %s, the attribute has no registered unit", from.Code)
+	}
+	return from.Convert(match[1], to)
+}
+
+// BaseValue returns a stored NUMBER value in the base unit of the attribute's
+// dimension, e.g. 16 GB as 16000000000 bytes, so that values of attributes in
+// different units compare. Numbers without a registered unit are returned as
+// they are; nil is returned for other data types and invalid values.
+func (a *Attribute) BaseValue(value string) *float64 {
+	if a.DataType != DataTypeNumber {
+		return nil
+	}
+
+	number, err := a.parseNumber(value)
+	if err != nil {
+		return nil
+	}
+	if unit, ok := a.Unit(); ok {
+		number, err = unit.Convert(formatNumber(number, 64), unit.BaseUnit())
+		if err != nil {
+			return nil
+		}
+	}
+	return &number
+}
+
+// DisplayValuesIn joins stored values for display like DisplayValues, with the
+// numbers converted to unit, as in "1.5 TB" for 1500 on a GB attribute. Values of
+// an attribute whose unit does not convert to unit are displayed as stored.
+func (a *Attribute) DisplayValuesIn(unit *Unit, values ...string) string {
+	from, ok := a.Unit()
+	if !ok || from.Dimension != unit.Dimension {
+		return a.DisplayValues(values...)
+	}
+
+	display := make([]string, len(values))
+	for i, value := range values {
+		display[i] = value
+		if converted, err := from.Convert(value, unit); err == nil {
+			display[i] = formatNumber(converted, 64)
+		}
+	}
+	return fmt.Sprintf("%s %s", strings.Join(display, ", "), unit.Code)
+}
+
+// NumberValuePattern matches, as a Postgres regular expression, the stored
+// NUMBER values that are plain numbers, i.e. those that have a base value
+const NumberValuePattern = `^[-+]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][-+]?[0-9]+)?$`
+
+// RefreshBaseValues recomputes the base values of the attribute's SKU values,
+// e.g. after its unit or data type changed. NUMBER values still stored with a
+// unit are rewritten in the attribute's unit.
+func (a *Attribute) RefreshBaseValues(tx *gorm.DB) error {
+	if a.DataType != DataTypeNumber {
+		return tx.Unscoped().Model(&SkuAttributeValue{}).
+			Where("attribute_id = ? AND base_value IS NOT NULL", a.ID).
+			UpdateColumn("base_value", nil).Error
+	}
+
+	_, err := a.computeBaseValues(tx, tx.Unscoped().Where("attribute_id = ?", a.ID))
+	return err
+}
+
+// ConvertValues rewrites the attribute's stored numbers from unit from to the
+// attribute's unit, e.g. 1000 as 1 after GB became TB, together with their base
+// values. Values that are not numbers are kept as they are.
+func (a *Attribute) ConvertValues(tx *gorm.DB, from *Unit) (int64, error) {
+	to, ok := a.Unit()
+	if !ok {
+		return 0, fmt.Errorf("cannot convert %s, the attribute has no registered unit", from.Code)
+	}
+	previous := *a
+	previous.UOM = from.Code
+
+	var values []SkuAttributeValue
+	var written int64
+	err := tx.Unscoped().Where("attribute_id = ?", a.ID).Select("id", "value").
+		FindInBatches(&values, 500, func(_ *gorm.DB, _ int) error {
+			updates := make([]storedValueUpdate, len(values))
+			for i, value := range values {
+				updates[i] = storedValueUpdate{ID: value.ID, Value: value.Value}
+				if number, err := previous.parseNumber(value.Value); err == nil {
+					if converted, err := from.Convert(formatNumber(number, 64), to); err == nil {
+						updates[i].Value = formatNumber(converted, 64)
+					}
+				}
+				updates[i].BaseValue = a.BaseValue(updates[i].Value)
+			}
+			written += int64(len(updates))
+			return updateStoredValues(tx, updates)
+		}).Error
+	return written, err
+}
+
+// FillMissingBaseValues computes the base values of the attribute's numbers
+// that have none yet, such as those stored before base values existed, and
+// returns how many it filled. Values that are not plain numbers are skipped, so
+// a value that has no base value by design is not read again on the next call.
+func (a *Attribute) FillMissingBaseValues(tx *gorm.DB) (int64, error) {
+	if a.DataType != DataTypeNumber {
+		return 0, nil
+	}
+
+	return a.computeBaseValues(tx, tx.Unscoped().
+		Where("attribute_id = ? AND base_value IS NULL AND value ~ ?", a.ID, NumberValuePattern))
+}
+
+// computeBaseValues stores the base value of every SKU value the query selects,
+// one batch at a time, and returns the number of values written
+func (a *Attribute) computeBaseValues(tx *gorm.DB, query *gorm.DB) (int64, error) {
+	var values []SkuAttributeValue
+	var written int64
+	err := query.Select("id", "value").
+		FindInBatches(&values, 500, func(_ *gorm.DB, _ int) error {
+			updates := make([]storedValueUpdate, len(values))
+			for i, value := range values {
+				updates[i] = storedValueUpdate{ID: value.ID, Value: value.Value, BaseValue: a.BaseValue(value.Value)}
+				if _, err := strconv.ParseFloat(value.Value, 64); err != nil {
+					if number, err := a.parseNumber(value.Value); err == nil {
+						updates[i].Value = formatNumber(number, 64)
+					}
+				}
+			}
+			written += int64(len(updates))
+			return updateStoredValues(tx, updates)
+		}).Error
+	return written, err
+}
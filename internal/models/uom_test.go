@@ -0,0 +1,179 @@
+package models
+
+import (
+	"testing"
+)
+
+// TestLookupUnit tests finding units by code and alias
+func TestLookupUnit(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+		found    bool
+	}{
+		{name: "Code", input: "GB", expected: "GB", found: true},
+		{name: "Alias", input: "inch", expected: "in", found: true},
+		{name: "Case is ignored", input: "ghz", expected: "GHz", found: true},
+		{name: "Surrounding spaces", input: " years ", expected: "yr", found: true},
+		{name: "Binary storage unit", input: "GiB", expected: "GiB", found: true},
+		{name: "Unknown unit", input: "parsec", found: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			unit, ok := LookupUnit(tc.input)
+			if ok != tc.found {
+				t.Fatalf("Expected found=%v for %q, got %v", tc.found, tc.input, ok)
+			}
+			if ok && unit.Code != tc.expected {
+				t.Errorf("Expected unit %s for %q, got %s", tc.expected, tc.input, unit.Code)
+			}
+		})
+	}
+}
+
+// TestUnitCatalog tests that every dimension starts with its base unit
+func TestUnitCatalog(t *testing.T) {
+	for _, unit := range Units() {
+		base := unit.BaseUnit()
+		if base.Factor != "1" {
+			t.Errorf("Expected base unit of %s to have factor 1, got %s (%s)", unit.Dimension, base.Factor, base.Code)
+		}
+	}
+}
+
+// TestUnitConvert tests converting between units
+func TestUnitConvert(t *testing.T) {
+	testCases := []struct {
+		name        string
+		value       string
+		from        string
+		to          string
+		expected    float64
+		expectError bool
+	}{
+		{name: "Terabytes to gigabytes", value: "1", from: "TB", to: "GB", expected: 1000},
+		{name: "Megabytes to gigabytes", value: "512", from: "MB", to: "GB", expected: 0.512},
+		{name: "Gibibytes to gigabytes", value: "1", from: "GiB", to: "GB", expected: 1.073741824},
+		{name: "Inches to centimetres", value: "15.6", from: "in", to: "cm", expected: 39.624},
+		{name: "Decimals convert exactly", value: "0.1", from: "GHz", to: "MHz", expected: 100},
+		{name: "Months to years", value: "24", from: "mo", to: "yr", expected: 2},
+		{name: "Pounds to kilograms", value: "2", from: "lb", to: "kg", expected: 0.90718474},
+		{name: "Different dimensions", value: "1", from: "GB", to: "GHz", expectError: true},
+		{name: "Invalid number", value: "many", from: "GB", to: "MB", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			from, _ := LookupUnit(tc.from)
+			to, _ := LookupUnit(tc.to)
+
+			result, err := from.Convert(tc.value, to)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Expected error, got %v", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected %v %s, got %v", tc.expected, tc.to, result)
+			}
+		})
+	}
+}
+
+// TestAttributeParseValueWithUnit tests that numbers entered in another unit are converted
+func TestAttributeParseValueWithUnit(t *testing.T) {
+	storage := Attribute{DataType: DataTypeNumber, UOM: "GB"}
+
+	testCases := []struct {
+		name        string
+		attribute   Attribute
+		valueStr    string
+		expected    float64
+		expectError bool
+	}{
+		{name: "Plain number", attribute: storage, valueStr: "512", expected: 512},
+		{name: "Same unit", attribute: storage, valueStr: "512 GB", expected: 512},
+		{name: "Larger unit", attribute: storage, valueStr: "1 TB", expected: 1000},
+		{name: "Unit without space", attribute: storage, valueStr: "1.5TB", expected: 1500},
+		{name: "Unit alias", attribute: Attribute{DataType: DataTypeNumber, UOM: "in"}, valueStr: `15.6"`, expected: 15.6},
+		{name: "Incompatible unit", attribute: storage, valueStr: "3 GHz", expectError: true},
+		{name: "Unknown unit", attribute: storage, valueStr: "3 parsecs", expectError: true},
+		{name: "Attribute without registered unit", attribute: Attribute{DataType: DataTypeNumber, UOM: "px"}, valueStr: "1 TB", expectError: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.attribute.ParseValue(tc.valueStr)
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Expected error for %q, got %v", tc.valueStr, result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error for %q: %v", tc.valueStr, err)
+			}
+			if result != tc.expected {
+				t.Errorf("Expected %v for %q, got %v", tc.expected, tc.valueStr, result)
+			}
+		})
+	}
+}
+
+// TestAttributeBaseValue tests normalizing stored numbers to the base unit
+func TestAttributeBaseValue(t *testing.T) {
+	testCases := []struct {
+		name      string
+		attribute Attribute
+		value     string
+		expected  *float64
+	}{
+		{name: "Gigabytes in bytes", attribute: Attribute{DataType: DataTypeNumber, UOM: "GB"}, value: "16", expected: floatPtr(16e9)},
+		{name: "Gigahertz in hertz", attribute: Attribute{DataType: DataTypeNumber, UOM: "GHz"}, value: "2.4", expected: floatPtr(2.4e9)},
+		{name: "Unregistered unit", attribute: Attribute{DataType: DataTypeNumber, UOM: "px"}, value: "1080", expected: floatPtr(1080)},
+		{name: "No unit", attribute: Attribute{DataType: DataTypeNumber}, value: "4", expected: floatPtr(4)},
+		{name: "Not a number", attribute: Attribute{DataType: DataTypeText, UOM: "GB"}, value: "16", expected: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := tc.attribute.BaseValue(tc.value)
+			if tc.expected == nil || result == nil {
+				if tc.expected != result {
+					t.Errorf("Expected %v, got %v", tc.expected, result)
+				}
+				return
+			}
+			if *result != *tc.expected {
+				t.Errorf("Expected %v, got %v", *tc.expected, *result)
+			}
+		})
+	}
+}
+
+// TestAttributeDisplayValuesIn tests displaying numbers in another unit
+func TestAttributeDisplayValuesIn(t *testing.T) {
+	terabytes, _ := LookupUnit("TB")
+	megahertz, _ := LookupUnit("MHz")
+	storage := Attribute{DataType: DataTypeNumber, UOM: "GB", MultiValued: true}
+
+	if got := storage.DisplayValuesIn(terabytes, "1500"); got != "1.5 TB" {
+		t.Errorf("Expected '1.5 TB', got %q", got)
+	}
+	if got := storage.DisplayValuesIn(terabytes, "256", "512"); got != "0.256, 0.512 TB" {
+		t.Errorf("Expected '0.256, 0.512 TB', got %q", got)
+	}
+	if got := storage.DisplayValuesIn(megahertz, "512"); got != "512 GB" {
+		t.Errorf("Expected an incompatible unit to be ignored, got %q", got)
+	}
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
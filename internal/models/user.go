@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"github.com/Wilson1510/klampis-pim-go/pkg/utils"
 	"gorm.io/gorm"
 )
 
@@ -13,6 +14,10 @@ const (
 	RoleUser   UserRole = "USER"
 )
 
+// SystemUsername is the username of the seeded account used for audit fields
+// when no user is acting, such as in background jobs and migrations
+const SystemUsername = "system"
+
 type User struct {
 	gorm.Model
 	Username string   `gorm:"uniqueIndex;not null;type:varchar(50)" json:"username"`
@@ -23,12 +28,34 @@ type User struct {
 
 // BeforeCreate is a GORM hook that runs before creating a record
 func (u *User) BeforeCreate(tx *gorm.DB) error {
-	return u.validateRole()
+	if err := u.validateRole(); err != nil {
+		return err
+	}
+	return u.hashPassword()
 }
 
 // BeforeUpdate is a GORM hook that runs before updating a record
 func (u *User) BeforeUpdate(tx *gorm.DB) error {
-	return u.validateRole()
+	if err := u.validateRole(); err != nil {
+		return err
+	}
+	return u.hashPassword()
+}
+
+// hashPassword replaces a plaintext password with its bcrypt hash.
+// Passwords that are already hashed are left untouched.
+func (u *User) hashPassword() error {
+	if u.Password == "" || utils.IsPasswordHash(u.Password) {
+		return nil
+	}
+
+	hash, err := utils.HashPassword(u.Password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	u.Password = hash
+	return nil
 }
 
 // validateRole checks if the role is valid
@@ -43,3 +70,8 @@ func (u *User) validateRole() error {
 
 	return fmt.Errorf("invalid role: %s. Valid roles are: %v", u.Role, validRoles)
 }
+
+// CheckPassword reports whether password matches the stored password hash
+func (u *User) CheckPassword(password string) bool {
+	return utils.VerifyPassword(u.Password, password)
+}
@@ -90,3 +90,52 @@ func TestValidateRole(t *testing.T) {
 		})
 	}
 }
+
+// TestHashPassword tests the hashPassword method (Pure Unit Test)
+func TestHashPassword(t *testing.T) {
+	t.Run("Plaintext password is hashed", func(t *testing.T) {
+		user := User{Password: "password123"}
+
+		if err := user.hashPassword(); err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+		if user.Password == "password123" {
+			t.Error("Expected password to be hashed")
+		}
+		if !user.CheckPassword("password123") {
+			t.Error("Expected CheckPassword to accept the original password")
+		}
+		if user.CheckPassword("wrong") {
+			t.Error("Expected CheckPassword to reject a wrong password")
+		}
+	})
+
+	t.Run("Existing hash is left untouched", func(t *testing.T) {
+		user := User{Password: "password123"}
+		if err := user.hashPassword(); err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+		hash := user.Password
+
+		if err := user.hashPassword(); err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+		if user.Password != hash {
+			t.Error("Expected an already hashed password not to be hashed again")
+		}
+	})
+
+	t.Run("Empty password stays empty", func(t *testing.T) {
+		user := User{}
+
+		if err := user.hashPassword(); err != nil {
+			t.Fatalf("Expected no error, but got: %v", err)
+		}
+		if user.Password != "" {
+			t.Errorf("Expected empty password, but got '%s'", user.Password)
+		}
+		if user.CheckPassword("") {
+			t.Error("Expected CheckPassword to reject an empty stored password")
+		}
+	})
+}
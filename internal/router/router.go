@@ -0,0 +1,125 @@
+package router
+
+import (
+	"net/http"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/auth"
+	"github.com/Wilson1510/klampis-pim-go/internal/config"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/response"
+	"github.com/Wilson1510/klampis-pim-go/internal/handler"
+	"github.com/Wilson1510/klampis-pim-go/internal/middleware"
+	"github.com/Wilson1510/klampis-pim-go/internal/service"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// New builds the HTTP handler with every /api/v1 route mounted
+func New(config *config.Config, db *gorm.DB) *gin.Engine {
+	if !config.App.Debug {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	r := gin.New()
+	r.HandleMethodNotAllowed = true
+	r.Use(gin.Logger(), gin.Recovery())
+
+	r.NoRoute(func(c *gin.Context) {
+		c.JSON(http.StatusNotFound, response.NewErrorResponse(
+			response.ErrCodeNotFound, "Route not found", nil))
+	})
+	r.NoMethod(func(c *gin.Context) {
+		c.JSON(http.StatusMethodNotAllowed, response.NewErrorResponse(
+			response.ErrCodeMethodNotAllowed, "Method not allowed", nil))
+	})
+
+	authService := service.NewAuthService(db, auth.NewTokenManager(&config.JWT))
+	mountRoutes(r, config, db, authService, middleware.Auth(authService))
+
+	return r
+}
+
+// mountRoutes registers the /api/v1 routes on r, guarding the admin endpoints
+// with requireAuth
+func mountRoutes(r *gin.Engine, config *config.Config, db *gorm.DB, authService *service.AuthService, requireAuth gin.HandlerFunc) {
+	cursors := service.NewCursorCodec(config.App.CursorSecret)
+
+	healthHandler := handler.NewHealthHandler(db)
+	authHandler := handler.NewAuthHandler(authService)
+	categoryHandler := handler.NewCategoryHandler(service.NewCategoryService(db, cursors))
+	productHandler := handler.NewProductHandler(service.NewProductService(db, cursors))
+	skuHandler := handler.NewSkuHandler(service.NewSkuService(db, cursors))
+	attributeHandler := handler.NewAttributeHandler(service.NewAttributeService(db, cursors))
+	userHandler := handler.NewUserHandler(service.NewUserService(db, cursors))
+
+	v1 := r.Group("/api/v1")
+	v1.GET("/health", healthHandler.Check)
+
+	authRoutes := v1.Group("/auth")
+	authRoutes.POST("/login", authHandler.Login)
+	authRoutes.POST("/refresh", authHandler.Refresh)
+	authRoutes.POST("/logout", requireAuth, authHandler.Logout)
+
+	// Public catalog endpoints need no authentication and only expose active records
+	catalog := v1.Group("/catalog")
+	catalog.GET("/categories/path/*path", categoryHandler.GetByPath)
+	catalog.GET("/products/:slug", productHandler.GetBySlug)
+	catalog.GET("/skus/:slug", skuHandler.GetBySlug)
+
+	// Admin endpoints require an authenticated user whose role grants the
+	// action on the resource, as defined by the auth permission matrix
+	admin := v1.Group("", requireAuth)
+	can := middleware.Authorize
+
+	categories := admin.Group("/categories")
+	categories.GET("", can(auth.ResourceCategories, auth.ActionRead), categoryHandler.List)
+	categories.POST("", can(auth.ResourceCategories, auth.ActionCreate), categoryHandler.Create)
+	categories.GET("/tree", can(auth.ResourceCategories, auth.ActionRead), categoryHandler.Tree)
+	categories.GET("/:id", can(auth.ResourceCategories, auth.ActionRead), categoryHandler.Get)
+	categories.PUT("/:id", can(auth.ResourceCategories, auth.ActionUpdate), categoryHandler.Update)
+	categories.DELETE("/:id", can(auth.ResourceCategories, auth.ActionDelete), categoryHandler.Delete)
+	categories.POST("/:id/move", can(auth.ResourceCategories, auth.ActionUpdate), categoryHandler.Move)
+	categories.GET("/:id/children", can(auth.ResourceCategories, auth.ActionRead), categoryHandler.Children)
+	categories.GET("/:id/products", can(auth.ResourceProducts, auth.ActionRead), categoryHandler.Products)
+
+	products := admin.Group("/products")
+	products.GET("", can(auth.ResourceProducts, auth.ActionRead), productHandler.List)
+	products.POST("", can(auth.ResourceProducts, auth.ActionCreate), productHandler.Create)
+	products.GET("/:id", can(auth.ResourceProducts, auth.ActionRead), productHandler.Get)
+	products.PUT("/:id", can(auth.ResourceProducts, auth.ActionUpdate), productHandler.Update)
+	products.DELETE("/:id", can(auth.ResourceProducts, auth.ActionDelete), productHandler.Delete)
+	products.GET("/:id/skus", can(auth.ResourceSkus, auth.ActionRead), productHandler.Skus)
+
+	// Attribute values are part of the SKU, so changing them is a SKU update
+	// and removing them is a SKU delete
+	skus := admin.Group("/skus")
+	skus.GET("", can(auth.ResourceSkus, auth.ActionRead), skuHandler.List)
+	skus.POST("", can(auth.ResourceSkus, auth.ActionCreate), skuHandler.Create)
+	skus.GET("/:id", can(auth.ResourceSkus, auth.ActionRead), skuHandler.Get)
+	skus.PUT("/:id", can(auth.ResourceSkus, auth.ActionUpdate), skuHandler.Update)
+	skus.DELETE("/:id", can(auth.ResourceSkus, auth.ActionDelete), skuHandler.Delete)
+	skus.GET("/:id/attributes", can(auth.ResourceSkus, auth.ActionRead), skuHandler.Attributes)
+	skus.POST("/:id/attributes", can(auth.ResourceSkus, auth.ActionUpdate), skuHandler.UpsertAttributes)
+	skus.DELETE("/:id/attributes/:attribute_id", can(auth.ResourceSkus, auth.ActionDelete), skuHandler.RemoveAttribute)
+
+	attributes := admin.Group("/attributes")
+	attributes.GET("", can(auth.ResourceAttributes, auth.ActionRead), attributeHandler.List)
+	attributes.POST("", can(auth.ResourceAttributes, auth.ActionCreate), attributeHandler.Create)
+	attributes.GET("/:id", can(auth.ResourceAttributes, auth.ActionRead), attributeHandler.Get)
+	attributes.PUT("/:id", can(auth.ResourceAttributes, auth.ActionUpdate), attributeHandler.Update)
+	attributes.DELETE("/:id", can(auth.ResourceAttributes, auth.ActionDelete), attributeHandler.Delete)
+	attributes.GET("/:id/options", can(auth.ResourceAttributes, auth.ActionRead), attributeHandler.ListOptions)
+	attributes.POST("/:id/options", can(auth.ResourceAttributes, auth.ActionUpdate), attributeHandler.CreateOption)
+	attributes.POST("/:id/options/merge", can(auth.ResourceAttributes, auth.ActionDelete), attributeHandler.MergeOptions)
+	attributes.PUT("/:id/options/:option_id", can(auth.ResourceAttributes, auth.ActionUpdate), attributeHandler.UpdateOption)
+	attributes.DELETE("/:id/options/:option_id", can(auth.ResourceAttributes, auth.ActionDelete), attributeHandler.DeleteOption)
+
+	// The unit catalog NUMBER attributes are measured in
+	admin.GET("/uoms", can(auth.ResourceAttributes, auth.ActionRead), attributeHandler.Units)
+
+	users := admin.Group("/users")
+	users.GET("", can(auth.ResourceUsers, auth.ActionRead), userHandler.List)
+	users.POST("", can(auth.ResourceUsers, auth.ActionCreate), userHandler.Create)
+	users.GET("/:id", can(auth.ResourceUsers, auth.ActionRead), userHandler.Get)
+	users.PUT("/:id", can(auth.ResourceUsers, auth.ActionUpdate), userHandler.Update)
+	users.DELETE("/:id", can(auth.ResourceUsers, auth.ActionDelete), userHandler.Delete)
+}
@@ -0,0 +1,65 @@
+package router
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/auth"
+	"github.com/Wilson1510/klampis-pim-go/internal/config"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/response"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRoutePermissions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+
+	// Every request is authenticated as a USER, so the permission matrix
+	// alone decides whether the handler is reached
+	asUser := func(c *gin.Context) {
+		user := &models.User{Role: models.RoleUser}
+		c.Request = c.Request.WithContext(auth.WithUser(c.Request.Context(), user, nil))
+	}
+
+	r := gin.New()
+	mountRoutes(r, &config.Config{App: config.AppConfig{CursorSecret: "secret"}}, db, nil, asUser)
+
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{name: "Remove SKU attribute value", method: http.MethodDelete, path: "/api/v1/skus/1/attributes/2"},
+		{name: "Merge attribute options", method: http.MethodPost, path: "/api/v1/attributes/1/options/merge", body: `{"source_option_ids": [4], "target_option_id": 2}`},
+		{name: "Delete attribute option", method: http.MethodDelete, path: "/api/v1/attributes/1/options/2"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusForbidden, rec.Code)
+			var body response.ErrorResponse
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, response.ErrCodeForbidden, body.Error.Code)
+		})
+	}
+}
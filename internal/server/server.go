@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/config"
+	"gorm.io/gorm"
+)
+
+// shutdownTimeout is how long in-flight requests are given to finish on shutdown
+const shutdownTimeout = 15 * time.Second
+
+// Server wraps the HTTP server together with the resources it owns
+type Server struct {
+	httpServer *http.Server
+	db         *gorm.DB
+}
+
+// New creates a Server listening on the configured application port
+func New(config *config.AppConfig, handler http.Handler, db *gorm.DB) *Server {
+	return &Server{
+		httpServer: &http.Server{
+			Addr:              fmt.Sprintf(":%d", config.Port),
+			Handler:           handler,
+			ReadHeaderTimeout: 10 * time.Second,
+			ReadTimeout:       30 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       60 * time.Second,
+		},
+		db: db,
+	}
+}
+
+// Run starts serving and blocks until ctx is cancelled or the listener fails.
+// On cancellation it stops accepting new connections, drains in-flight
+// requests and closes the database pool.
+func (s *Server) Run(ctx context.Context) error {
+	errCh := make(chan error, 1)
+
+	go func() {
+		log.Printf("HTTP server listening on %s", s.httpServer.Addr)
+		if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case err, ok := <-errCh:
+		// The listener stopped on its own; nothing is left to drain
+		s.closeDB()
+		if ok {
+			return fmt.Errorf("http server failed: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	log.Println("Shutdown signal received, draining in-flight requests")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	shutdownErr := s.httpServer.Shutdown(shutdownCtx)
+	s.closeDB()
+
+	if shutdownErr != nil {
+		return fmt.Errorf("graceful shutdown failed: %w", shutdownErr)
+	}
+
+	log.Println("HTTP server stopped gracefully")
+	return nil
+}
+
+// closeDB closes the underlying database connection pool
+func (s *Server) closeDB() {
+	if s.db == nil {
+		return
+	}
+
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		log.Printf("Failed to get database instance: %v", err)
+		return
+	}
+
+	if err := sqlDB.Close(); err != nil {
+		log.Printf("Failed to close database connection: %v", err)
+		return
+	}
+
+	log.Println("Database connection closed")
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunStopsOnContextCancel(t *testing.T) {
+	// Setup
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	srv := New(&config.AppConfig{Port: 0}, handler, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.Run(ctx)
+	}()
+
+	// Execute
+	cancel()
+
+	// Assert
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after context cancellation")
+	}
+}
+
+func TestRunReturnsListenError(t *testing.T) {
+	// Setup
+	srv := New(&config.AppConfig{Port: -1}, http.NotFoundHandler(), nil)
+
+	// Execute
+	err := srv.Run(context.Background())
+
+	// Assert
+	assert.Error(t, err)
+}
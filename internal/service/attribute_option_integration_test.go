@@ -0,0 +1,156 @@
+//go:build integration
+// +build integration
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/internal/service"
+	"github.com/Wilson1510/klampis-pim-go/internal/testutil"
+)
+
+func TestAttributeOptions_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testUser := models.User{Username: "testuser", Password: "password123", Name: "Test User", Role: models.RoleUser}
+	if err := db.Create(&testUser).Error; err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	audit := models.Base{CreatedBy: testUser.ID, UpdatedBy: testUser.ID}
+
+	category := models.Category{Base: audit, Name: "Apparel"}
+	db.Create(&category)
+	product := models.Product{Base: audit, Name: "Basic Tee", CategoryID: category.ID}
+	db.Create(&product)
+	skus := make([]models.Sku, 3)
+	for i, number := range []string{"TEE-1", "TEE-2", "TEE-3"} {
+		skus[i] = models.Sku{Base: audit, Name: "Basic Tee " + number, SkuNumber: number, Price: 10, ProductID: product.ID}
+		db.Create(&skus[i])
+	}
+
+	// Values entered while the attribute was still free text
+	color := models.Attribute{Base: audit, Name: "Color", Code: "color", DataType: models.DataTypeText}
+	db.Create(&color)
+	for i, value := range []string{"RED ", "Navy Blue", "crimson"} {
+		db.Create(&models.SkuAttributeValue{SkuID: skus[i].ID, AttributeID: color.ID, Value: value})
+	}
+
+	attributeService := service.NewAttributeService(db, service.NewCursorCodec("secret"))
+	ctx := context.Background()
+
+	createOption := func(code, label string) *models.AttributeOption {
+		t.Helper()
+		option, err := attributeService.CreateOption(ctx, color.ID, &request.CreateAttributeOptionRequest{Code: code, Label: label})
+		if err != nil {
+			t.Fatalf("Failed to create option %s: %v", code, err)
+		}
+		return option
+	}
+	red := createOption("red", "Red")
+	navy := createOption("navy", "Navy Blue")
+	crimson := createOption("crimson", "Crimson")
+
+	storedValue := func(skuID uint) string {
+		t.Helper()
+		var value models.SkuAttributeValue
+		if err := db.Where("sku_id = ? AND attribute_id = ?", skuID, color.ID).First(&value).Error; err != nil {
+			t.Fatalf("Failed to load value: %v", err)
+		}
+		return value.Value
+	}
+
+	t.Run("Clashing option is rejected", func(t *testing.T) {
+		_, err := attributeService.CreateOption(ctx, color.ID, &request.CreateAttributeOptionRequest{Code: "RED", Label: "Bright Red"})
+		var conflict *service.ConflictError
+		if !errors.As(err, &conflict) {
+			t.Errorf("Expected ConflictError, got %v", err)
+		}
+	})
+
+	t.Run("Switching to OPTION normalizes stored values", func(t *testing.T) {
+		dataType := string(models.DataTypeOption)
+		if _, _, err := attributeService.Update(ctx, color.ID, &request.UpdateAttributeRequest{DataType: &dataType}, false); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		for i, expected := range []string{"red", "navy", "crimson"} {
+			if got := storedValue(skus[i].ID); got != expected {
+				t.Errorf("Expected value %q for %s, got %q", expected, skus[i].SkuNumber, got)
+			}
+		}
+	})
+
+	t.Run("Values are stored by code", func(t *testing.T) {
+		value := models.SkuAttributeValue{SkuID: skus[0].ID, AttributeID: color.ID, Value: "Crimson"}
+		if err := db.Create(&value).Error; err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if value.Value != "crimson" {
+			t.Errorf("Expected value stored as 'crimson', got %q", value.Value)
+		}
+		db.Unscoped().Delete(&value)
+
+		invalid := models.SkuAttributeValue{SkuID: skus[0].ID, AttributeID: color.ID, Value: "green"}
+		if err := db.Create(&invalid).Error; err == nil {
+			t.Error("Expected an unknown option to be rejected")
+		}
+	})
+
+	t.Run("Used option cannot be deleted", func(t *testing.T) {
+		err := attributeService.DeleteOption(ctx, color.ID, navy.ID)
+		var conflict *service.ConflictError
+		if !errors.As(err, &conflict) {
+			t.Errorf("Expected ConflictError, got %v", err)
+		}
+	})
+
+	t.Run("Renaming a code rewrites values", func(t *testing.T) {
+		code := "navy-blue"
+		if _, err := attributeService.UpdateOption(ctx, color.ID, navy.ID, &request.UpdateAttributeOptionRequest{Code: &code}); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if got := storedValue(skus[1].ID); got != "navy-blue" {
+			t.Errorf("Expected value 'navy-blue', got %q", got)
+		}
+	})
+
+	t.Run("Merge into itself is rejected", func(t *testing.T) {
+		_, err := attributeService.MergeOptions(ctx, color.ID, &request.MergeAttributeOptionsRequest{
+			SourceOptionIDs: []uint{red.ID},
+			TargetOptionID:  red.ID,
+		})
+		var validationErr *service.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected ValidationError, got %v", err)
+		}
+	})
+
+	t.Run("Merge rewrites values and removes sources", func(t *testing.T) {
+		result, err := attributeService.MergeOptions(ctx, color.ID, &request.MergeAttributeOptionsRequest{
+			SourceOptionIDs: []uint{crimson.ID},
+			TargetOptionID:  red.ID,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if result.MergedOptions != 1 || result.UpdatedValues != 1 {
+			t.Errorf("Expected 1 merged option and 1 updated value, got %d and %d", result.MergedOptions, result.UpdatedValues)
+		}
+		if got := storedValue(skus[2].ID); got != "red" {
+			t.Errorf("Expected value 'red', got %q", got)
+		}
+
+		options, err := attributeService.ListOptions(ctx, color.ID)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if len(options) != 2 {
+			t.Errorf("Expected 2 options left, got %d", len(options))
+		}
+	})
+}
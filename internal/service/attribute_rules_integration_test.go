@@ -0,0 +1,113 @@
+//go:build integration
+// +build integration
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/response"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/internal/service"
+	"github.com/Wilson1510/klampis-pim-go/internal/testutil"
+)
+
+func TestAttributeRules_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testUser := models.User{Username: "testuser", Password: "password123", Name: "Test User", Role: models.RoleUser}
+	if err := db.Create(&testUser).Error; err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	audit := models.Base{CreatedBy: testUser.ID, UpdatedBy: testUser.ID}
+
+	category := models.Category{Base: audit, Name: "Laptops"}
+	db.Create(&category)
+	product := models.Product{Base: audit, Name: "ROG Strix", CategoryID: category.ID}
+	db.Create(&product)
+	sku := models.Sku{Base: audit, Name: "ROG Strix 16GB", SkuNumber: "ROG-16", Price: 100, ProductID: product.ID}
+	db.Create(&sku)
+
+	attributeService := service.NewAttributeService(db, service.NewCursorCodec("secret"))
+	skuService := service.NewSkuService(db, service.NewCursorCodec("secret"))
+	ctx := context.Background()
+
+	zero, fifty := 0.0, 50
+	screen, err := attributeService.Create(ctx, &request.CreateAttributeRequest{
+		Name: "Screen Size", Code: "screen_size", DataType: "NUMBER", UOM: "inch",
+		Rules: &request.AttributeRulesRequest{MinValue: &zero},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create attribute: %v", err)
+	}
+	color, err := attributeService.Create(ctx, &request.CreateAttributeRequest{Name: "Color", Code: "color", DataType: "TEXT"})
+	if err != nil {
+		t.Fatalf("Failed to create attribute: %v", err)
+	}
+
+	t.Run("Rule of another data type is rejected", func(t *testing.T) {
+		_, err := attributeService.Create(ctx, &request.CreateAttributeRequest{
+			Name: "Weight", Code: "weight", DataType: "TEXT",
+			Rules: &request.AttributeRulesRequest{MinValue: &zero},
+		})
+		var validationErr *service.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected ValidationError, got %v", err)
+		}
+	})
+
+	t.Run("Broken rule is reported with details", func(t *testing.T) {
+		_, err := skuService.UpsertAttributes(ctx, sku.ID, &request.UpsertSkuAttributesRequest{
+			Attributes: []request.SkuAttributeValueInput{{AttributeID: screen.ID, Value: "-3"}},
+		})
+		var validationErr *service.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("Expected ValidationError, got %v", err)
+		}
+		details, ok := validationErr.Details.([]response.AttributeValueError)
+		if !ok || len(details) != 1 {
+			t.Fatalf("Expected one value error, got %#v", validationErr.Details)
+		}
+		if details[0].Rule != "min_value" || details[0].Limit != 0.0 {
+			t.Errorf("Expected min_value rule with limit 0, got %s %v", details[0].Rule, details[0].Limit)
+		}
+	})
+
+	t.Run("Model hook enforces rules", func(t *testing.T) {
+		value := models.SkuAttributeValue{SkuID: sku.ID, AttributeID: screen.ID, Value: "-3"}
+		err := db.Create(&value).Error
+		var violation *models.RuleViolationError
+		if !errors.As(err, &violation) {
+			t.Errorf("Expected RuleViolationError, got %v", err)
+		}
+	})
+
+	t.Run("Tightening rules is checked against stored values", func(t *testing.T) {
+		_, err := skuService.UpsertAttributes(ctx, sku.ID, &request.UpsertSkuAttributesRequest{
+			Attributes: []request.SkuAttributeValueInput{{AttributeID: color.ID, Value: strings.Repeat("x", 80)}},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		rules := &request.AttributeRulesRequest{MaxLength: &fifty}
+		_, report, err := attributeService.Update(ctx, color.ID, &request.UpdateAttributeRequest{Rules: rules}, true)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if report == nil || report.IncompatibleCount != 1 {
+			t.Fatalf("Expected one incompatible value in the preview, got %+v", report)
+		}
+
+		_, _, err = attributeService.Update(ctx, color.ID, &request.UpdateAttributeRequest{Rules: rules}, false)
+		var conflict *service.ConflictError
+		if !errors.As(err, &conflict) {
+			t.Errorf("Expected ConflictError, got %v", err)
+		}
+	})
+}
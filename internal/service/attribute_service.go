@@ -0,0 +1,610 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/mapper"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// attributeListSpec whitelists what attribute listings may filter and sort on
+var attributeListSpec = listSpec{
+	filterFields: []string{"id", "name", "code", "data_type", "uom", "is_active", "sequence", "created_at", "updated_at"},
+	sortFields:   sortColumns("id", "name", "code", "data_type", "uom", "is_active", "sequence", "created_at", "updated_at"),
+}
+
+// AttributeService contains the business logic for attribute master data
+type AttributeService struct {
+	db      *gorm.DB
+	cursors *CursorCodec
+}
+
+// NewAttributeService creates a new AttributeService
+func NewAttributeService(db *gorm.DB, cursors *CursorCodec) *AttributeService {
+	return &AttributeService{db: db, cursors: cursors}
+}
+
+// List returns a page of attributes matching the request filters
+func (s *AttributeService) List(ctx context.Context, req *request.GetAttributesRequest) ([]models.Attribute, *PageInfo, error) {
+	query := s.db.WithContext(ctx).Model(&models.Attribute{})
+
+	if req.Name != "" {
+		query = query.Where("name ILIKE ?", "%"+req.Name+"%")
+	}
+	if req.Code != "" {
+		query = query.Where("code = ?", req.Code)
+	}
+	if req.DataType != "" {
+		query = query.Where("data_type = ?", req.DataType)
+	}
+
+	var attributes []models.Attribute
+	page, err := paginate(query, &req.PaginationRequest, attributeListSpec, s.cursors, &attributes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return attributes, page, nil
+}
+
+// Get returns a single attribute with its options
+func (s *AttributeService) Get(ctx context.Context, id uint) (*models.Attribute, error) {
+	var attribute models.Attribute
+	err := s.db.WithContext(ctx).
+		Preload("Options", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sequence, id")
+		}).
+		First(&attribute, id).Error
+	if err != nil {
+		return nil, translateNotFound(err)
+	}
+
+	return &attribute, nil
+}
+
+// Create creates a new attribute
+func (s *AttributeService) Create(ctx context.Context, req *request.CreateAttributeRequest) (*models.Attribute, error) {
+	db := s.db.WithContext(ctx)
+
+	if err := s.ensureCodeAvailable(db, req.Code, 0); err != nil {
+		return nil, err
+	}
+
+	attribute := models.Attribute{
+		Name:        req.Name,
+		Code:        req.Code,
+		DataType:    models.DataType(req.DataType),
+		UOM:         req.UOM,
+		MultiValued: req.MultiValued,
+	}
+	if req.IsActive != nil {
+		attribute.IsActive = *req.IsActive
+	}
+	if err := normalizeUOM(&attribute); err != nil {
+		return nil, err
+	}
+	if err := applyRules(&attribute, req.Rules); err != nil {
+		return nil, err
+	}
+	if err := db.Create(&attribute).Error; err != nil {
+		return nil, err
+	}
+
+	return &attribute, nil
+}
+
+// Update applies the non-nil fields of the request to an existing attribute.
+//
+// A data type or rules change is only saved when every stored value is still
+// valid; otherwise a ConflictError carrying the impact report is returned.
+// With dryRun set nothing is saved and the impact report is returned instead.
+// Values switched to OPTION are rewritten to the code of the option they match,
+// and values switched to DATE to their ISO form.
+// MultiValued can only be switched off while no SKU holds more than one value.
+// A new UOM of the same dimension converts the stored numbers, so 1000 GB
+// become 1 TB; any other new UOM relabels them, which has to be requested with
+// RelabelValues while values are stored.
+func (s *AttributeService) Update(ctx context.Context, id uint, req *request.UpdateAttributeRequest, dryRun bool) (*models.Attribute, *models.DataTypeChangeReport, error) {
+	db := s.db.WithContext(ctx)
+
+	var attribute models.Attribute
+	if err := db.First(&attribute, id).Error; err != nil {
+		return nil, nil, translateNotFound(err)
+	}
+	originalDataType, originalUOM, originalRules := attribute.DataType, attribute.UOM, attribute.Rules
+
+	if req.Name != nil {
+		attribute.Name = *req.Name
+	}
+	if req.Code != nil {
+		if err := s.ensureCodeAvailable(db, *req.Code, attribute.ID); err != nil {
+			return nil, nil, err
+		}
+		attribute.Code = *req.Code
+	}
+	if req.DataType != nil {
+		attribute.DataType = models.DataType(*req.DataType)
+	}
+	if req.UOM != nil {
+		attribute.UOM = *req.UOM
+	}
+	if req.UOM != nil || req.DataType != nil {
+		if err := normalizeUOM(&attribute); err != nil {
+			return nil, nil, err
+		}
+	}
+	convertFrom, err := unitConversion(db, &attribute, originalDataType, originalUOM, req.RelabelValues)
+	if err != nil {
+		return nil, nil, err
+	}
+	if req.MultiValued != nil {
+		if attribute.MultiValued && !*req.MultiValued {
+			if err := ensureSingleValues(db, attribute.ID); err != nil {
+				return nil, nil, err
+			}
+		}
+		attribute.MultiValued = *req.MultiValued
+	}
+	if req.IsActive != nil {
+		attribute.IsActive = *req.IsActive
+	}
+	if err := applyRules(&attribute, req.Rules); err != nil {
+		return nil, nil, err
+	}
+
+	if dryRun {
+		if attribute.DataType == originalDataType && attribute.Rules.Equal(&originalRules) {
+			return &attribute, nil, nil
+		}
+		report, err := attribute.CheckDataTypeChange(db, attribute.DataType)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &attribute, report, nil
+	}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		// BeforeUpdate re-validates stored values when the data type changes
+		if err := tx.Omit(clause.Associations).Save(&attribute).Error; err != nil {
+			return err
+		}
+		if convertFrom != nil {
+			if _, err := attribute.ConvertValues(tx, convertFrom); err != nil {
+				return err
+			}
+		} else if attribute.DataType != originalDataType || attribute.UOM != originalUOM {
+			if err := attribute.RefreshBaseValues(tx); err != nil {
+				return err
+			}
+		}
+		if attribute.DataType == models.DataTypeDate && originalDataType != models.DataTypeDate {
+			if _, err := attribute.CanonicalizeDates(tx); err != nil {
+				return err
+			}
+		}
+		if attribute.DataType == models.DataTypeOption && originalDataType != models.DataTypeOption {
+			if _, err := normalizeOptionValues(tx, attribute.ID); err != nil {
+				return err
+			}
+			return removeRepeatedValues(tx, attribute.ID)
+		}
+		return nil
+	})
+	var changeErr *models.DataTypeChangeError
+	if errors.As(err, &changeErr) {
+		return nil, nil, NewConflictError(mapper.ToDataTypeChangeResponse(changeErr.Report),
+			"%d stored value(s) are incompatible with data type %s and its rules",
+			changeErr.Report.IncompatibleCount, changeErr.Report.To)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &attribute, nil, nil
+}
+
+// Delete removes an attribute that is not used by any SKU
+func (s *AttributeService) Delete(ctx context.Context, id uint) error {
+	db := s.db.WithContext(ctx)
+
+	var attribute models.Attribute
+	if err := db.First(&attribute, id).Error; err != nil {
+		return translateNotFound(err)
+	}
+
+	var valueCount int64
+	if err := db.Model(&models.SkuAttributeValue{}).Where("attribute_id = ?", id).Count(&valueCount).Error; err != nil {
+		return err
+	}
+	if valueCount > 0 {
+		return NewConflictError(map[string]int64{"sku_attribute_values": valueCount},
+			"attribute %d is still used by SKUs", id)
+	}
+
+	return db.Delete(&attribute).Error
+}
+
+// Units returns the catalog of units a NUMBER attribute can be measured in
+func (s *AttributeService) Units() []models.Unit {
+	return models.Units()
+}
+
+// OptionMergeResult reports the outcome of MergeOptions
+type OptionMergeResult struct {
+	Option        models.AttributeOption
+	MergedOptions int
+	UpdatedValues int64
+}
+
+// ListOptions returns the options of an attribute ordered by sequence. Options can
+// be prepared before an attribute is switched to the OPTION data type.
+func (s *AttributeService) ListOptions(ctx context.Context, attributeID uint) ([]models.AttributeOption, error) {
+	db := s.db.WithContext(ctx)
+
+	if err := ensureExists(db, &models.Attribute{}, attributeID, ""); err != nil {
+		return nil, err
+	}
+
+	var options []models.AttributeOption
+	if err := db.Where("attribute_id = ?", attributeID).Order("sequence, id").Find(&options).Error; err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+// CreateOption adds an option to an attribute
+func (s *AttributeService) CreateOption(ctx context.Context, attributeID uint, req *request.CreateAttributeOptionRequest) (*models.AttributeOption, error) {
+	db := s.db.WithContext(ctx)
+
+	if err := ensureExists(db, &models.Attribute{}, attributeID, ""); err != nil {
+		return nil, err
+	}
+	if err := ensureOptionAvailable(db, attributeID, req.Code, req.Label, 0); err != nil {
+		return nil, err
+	}
+
+	option := models.AttributeOption{
+		AttributeID: attributeID,
+		Code:        req.Code,
+		Label:       req.Label,
+	}
+	if req.Sequence != nil {
+		option.Sequence = *req.Sequence
+	}
+	if err := db.Create(&option).Error; err != nil {
+		return nil, err
+	}
+
+	return &option, nil
+}
+
+// UpdateOption applies the non-nil fields of the request to an option. A new
+// code is also written to every SKU value that used the old one.
+func (s *AttributeService) UpdateOption(ctx context.Context, attributeID, optionID uint, req *request.UpdateAttributeOptionRequest) (*models.AttributeOption, error) {
+	var option models.AttributeOption
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("attribute_id = ?", attributeID).First(&option, optionID).Error; err != nil {
+			return translateNotFound(err)
+		}
+		previousCode := option.Code
+
+		if req.Code != nil {
+			option.Code = *req.Code
+		}
+		if req.Label != nil {
+			option.Label = *req.Label
+		}
+		if req.Sequence != nil {
+			option.Sequence = *req.Sequence
+		}
+		if err := ensureOptionAvailable(tx, attributeID, option.Code, option.Label, option.ID); err != nil {
+			return err
+		}
+
+		if err := tx.Omit(clause.Associations).Save(&option).Error; err != nil {
+			return err
+		}
+		if option.Code == previousCode {
+			return nil
+		}
+		_, err := replaceOptionValues(tx, attributeID, []string{previousCode}, option.Code)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &option, nil
+}
+
+// DeleteOption removes an option that no SKU uses
+func (s *AttributeService) DeleteOption(ctx context.Context, attributeID, optionID uint) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var option models.AttributeOption
+		if err := tx.Where("attribute_id = ?", attributeID).First(&option, optionID).Error; err != nil {
+			return translateNotFound(err)
+		}
+
+		var valueCount int64
+		err := tx.Model(&models.SkuAttributeValue{}).
+			Where("attribute_id = ? AND value = ?", attributeID, option.Code).
+			Count(&valueCount).Error
+		if err != nil {
+			return err
+		}
+		if valueCount > 0 {
+			return NewConflictError(map[string]int64{"sku_attribute_values": valueCount},
+				"option %s is still used by SKUs", option.Code)
+		}
+
+		// Deleted for good, so that the code can be used again
+		return tx.Unscoped().Delete(&option).Error
+	})
+}
+
+// MergeOptions folds duplicate options into a target option: SKU values of the
+// source options are rewritten to the target code and the sources are deleted
+func (s *AttributeService) MergeOptions(ctx context.Context, attributeID uint, req *request.MergeAttributeOptionsRequest) (*OptionMergeResult, error) {
+	result := &OptionMergeResult{}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("attribute_id = ?", attributeID).First(&result.Option, req.TargetOptionID).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return NewValidationError(map[string]string{"target_option_id": "is not an option of this attribute"},
+				"option %d is not an option of attribute %d", req.TargetOptionID, attributeID)
+		}
+		if err != nil {
+			return err
+		}
+
+		var sources []models.AttributeOption
+		if err := tx.Where("attribute_id = ? AND id IN ?", attributeID, req.SourceOptionIDs).Find(&sources).Error; err != nil {
+			return err
+		}
+		codes := make([]string, 0, len(sources))
+		for _, source := range sources {
+			if source.ID == result.Option.ID {
+				return NewValidationError(map[string]string{"source_option_ids": "must not contain the target option"},
+					"option %d cannot be merged into itself", source.ID)
+			}
+			codes = append(codes, source.Code)
+		}
+		if len(sources) != countDistinct(req.SourceOptionIDs) {
+			return NewValidationError(map[string]string{"source_option_ids": "contains options of another attribute or unknown options"},
+				"not every source option belongs to attribute %d", attributeID)
+		}
+
+		updated, err := replaceOptionValues(tx, attributeID, codes, result.Option.Code)
+		if err != nil {
+			return err
+		}
+		if err := removeRepeatedValues(tx, attributeID); err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Delete(&sources).Error; err != nil {
+			return err
+		}
+
+		result.MergedOptions, result.UpdatedValues = len(sources), updated
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// normalizeUOM checks that the unit of a NUMBER attribute is in the unit catalog
+// and stores it by its code, so that gb is saved as GB. Other data types keep
+// their UOM as a free display label.
+func normalizeUOM(attribute *models.Attribute) error {
+	if attribute.DataType != models.DataTypeNumber || attribute.UOM == "" {
+		return nil
+	}
+
+	unit, ok := models.LookupUnit(attribute.UOM)
+	if !ok {
+		return NewValidationError(map[string]string{"uom": "is not a registered unit, see GET /uoms"},
+			"unknown unit of measurement: %s", attribute.UOM)
+	}
+	attribute.UOM = unit.Code
+	return nil
+}
+
+// unitConversion decides what a UOM change does to the stored numbers of an
+// attribute. They are converted when the old and new unit measure the same
+// dimension, and returned is the unit to convert from. Otherwise they keep
+// their numbers, which is only allowed with relabel or while none are stored.
+func unitConversion(db *gorm.DB, attribute *models.Attribute, originalDataType models.DataType, originalUOM string, relabel bool) (*models.Unit, error) {
+	if attribute.DataType != models.DataTypeNumber || originalDataType != models.DataTypeNumber ||
+		attribute.UOM == originalUOM {
+		return nil, nil
+	}
+
+	from, fromOK := models.LookupUnit(originalUOM)
+	to, toOK := attribute.Unit()
+	if fromOK && toOK && from.Dimension == to.Dimension {
+		return from, nil
+	}
+	if relabel {
+		return nil, nil
+	}
+
+	var valueCount int64
+	if err := db.Model(&models.SkuAttributeValue{}).Where("attribute_id = ?", attribute.ID).Count(&valueCount).Error; err != nil {
+		return nil, err
+	}
+	if valueCount > 0 {
+		return nil, NewValidationError(map[string]string{"uom": "cannot convert the stored values, set relabel_values to keep their numbers"},
+			"%d stored value(s) cannot be converted from %q to %q", valueCount, originalUOM, attribute.UOM)
+	}
+	return nil, nil
+}
+
+// applyRules replaces the rules of an attribute with the requested ones, if any,
+// and checks that they fit its data type
+func applyRules(attribute *models.Attribute, req *request.AttributeRulesRequest) error {
+	if req != nil {
+		minDate, err := parseRuleDate("min_date", req.MinDate)
+		if err != nil {
+			return err
+		}
+		maxDate, err := parseRuleDate("max_date", req.MaxDate)
+		if err != nil {
+			return err
+		}
+		attribute.Rules = models.AttributeRules{
+			MinValue:  req.MinValue,
+			MaxValue:  req.MaxValue,
+			Precision: req.Precision,
+			MinLength: req.MinLength,
+			MaxLength: req.MaxLength,
+			Pattern:   req.Pattern,
+			MinDate:   minDate,
+			MaxDate:   maxDate,
+		}
+	}
+
+	err := attribute.Rules.Validate(attribute.DataType)
+	var invalid *models.InvalidRuleError
+	if errors.As(err, &invalid) {
+		return NewValidationError(map[string]string{"rules." + invalid.Rule: invalid.Message}, "%s", err)
+	}
+
+	return err
+}
+
+// parseRuleDate parses an optional YYYY-MM-DD date limit of the rules
+func parseRuleDate(field, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, NewValidationError(map[string]string{"rules." + field: "must be a date as YYYY-MM-DD"},
+			"invalid %s: %s", field, value)
+	}
+	return &date, nil
+}
+
+// ensureOptionAvailable checks that no other option of the attribute already uses
+// code or label. Case is ignored, as values are matched ignoring case too.
+func ensureOptionAvailable(db *gorm.DB, attributeID uint, code, label string, excludeID uint) error {
+	var clashes []models.AttributeOption
+	query := db.Where("attribute_id = ?", attributeID).
+		Where("lower(code) IN (lower(?), lower(?)) OR lower(label) IN (lower(?), lower(?))", code, label, code, label)
+	if excludeID != 0 {
+		query = query.Where("id != ?", excludeID)
+	}
+	if err := query.Limit(1).Find(&clashes).Error; err != nil {
+		return err
+	}
+	if len(clashes) > 0 {
+		return NewConflictError(map[string]uint{"option_id": clashes[0].ID},
+			"option %s (%s) clashes with option %s (%s)", code, label, clashes[0].Code, clashes[0].Label)
+	}
+
+	return nil
+}
+
+// replaceOptionValues rewrites the SKU values of an attribute from any of codes to code
+func replaceOptionValues(tx *gorm.DB, attributeID uint, codes []string, code string) (int64, error) {
+	if len(codes) == 0 {
+		return 0, nil
+	}
+	result := tx.Model(&models.SkuAttributeValue{}).
+		Where("attribute_id = ? AND value IN ?", attributeID, codes).
+		UpdateColumns(map[string]interface{}{"value": code, "updated_at": gorm.Expr("NOW()")})
+	return result.RowsAffected, result.Error
+}
+
+// normalizeOptionValues rewrites the SKU values of an attribute that match an
+// option by code or label, ignoring case and spaces, to the option code
+func normalizeOptionValues(tx *gorm.DB, attributeID uint) (int64, error) {
+	result := tx.Exec(`UPDATE sku_attribute_values AS v SET value = o.code, updated_at = NOW()
+		FROM attribute_options AS o
+		WHERE v.attribute_id = ? AND v.deleted_at IS NULL
+			AND o.attribute_id = v.attribute_id AND o.deleted_at IS NULL
+			AND lower(trim(v.value)) IN (lower(o.code), lower(o.label))
+			AND NOT EXISTS (SELECT 1 FROM attribute_options AS x
+				WHERE x.attribute_id = v.attribute_id AND x.code = v.value AND x.deleted_at IS NULL)`, attributeID)
+	return result.RowsAffected, result.Error
+}
+
+// removeRepeatedValues deletes the values that repeat an earlier value in the list
+// of a SKU, as left behind when options of a multi-valued attribute collapse
+// into one, and closes the gaps in the positions of the remaining values
+func removeRepeatedValues(tx *gorm.DB, attributeID uint) error {
+	result := tx.Exec(`DELETE FROM sku_attribute_values AS v
+		USING sku_attribute_values AS earlier
+		WHERE v.attribute_id = ? AND v.deleted_at IS NULL
+			AND earlier.sku_id = v.sku_id AND earlier.attribute_id = v.attribute_id
+			AND earlier.value = v.value AND earlier.position < v.position
+			AND earlier.deleted_at IS NULL`, attributeID)
+	if result.Error != nil || result.RowsAffected == 0 {
+		return result.Error
+	}
+
+	// Renumber through negative positions, which never clash with the unique
+	// index while the values are moved
+	err := tx.Exec(`UPDATE sku_attribute_values AS v SET position = -1 - numbered.position
+		FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY sku_id ORDER BY position) - 1 AS position
+			FROM sku_attribute_values WHERE attribute_id = ? AND deleted_at IS NULL) AS numbered
+		WHERE v.id = numbered.id AND v.position <> numbered.position`, attributeID).Error
+	if err != nil {
+		return err
+	}
+	return tx.Exec(`UPDATE sku_attribute_values SET position = -1 - position
+		WHERE attribute_id = ? AND position < 0`, attributeID).Error
+}
+
+// ensureSingleValues checks that no SKU holds more than one value of an attribute
+func ensureSingleValues(db *gorm.DB, attributeID uint) error {
+	var skuCount int64
+	err := db.Model(&models.SkuAttributeValue{}).
+		Where("attribute_id = ? AND position > 0", attributeID).
+		Distinct("sku_id").
+		Count(&skuCount).Error
+	if err != nil {
+		return err
+	}
+	if skuCount > 0 {
+		return NewConflictError(map[string]int64{"skus_with_multiple_values": skuCount},
+			"%d SKU(s) hold more than one value of attribute %d", skuCount, attributeID)
+	}
+
+	return nil
+}
+
+// countDistinct returns the number of distinct IDs
+func countDistinct(ids []uint) int {
+	seen := make(map[uint]struct{}, len(ids))
+	for _, id := range ids {
+		seen[id] = struct{}{}
+	}
+	return len(seen)
+}
+
+// ensureCodeAvailable checks that no other attribute already uses code
+func (s *AttributeService) ensureCodeAvailable(db *gorm.DB, code string, excludeID uint) error {
+	var count int64
+	query := db.Model(&models.Attribute{}).Where("code = ?", code)
+	if excludeID != 0 {
+		query = query.Where("id != ?", excludeID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return NewConflictError(map[string]string{"code": "is already in use"},
+			"attribute code %s is already in use", code)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+//go:build integration
+// +build integration
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/auth"
+	"github.com/Wilson1510/klampis-pim-go/internal/config"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/internal/service"
+	"github.com/Wilson1510/klampis-pim-go/internal/testutil"
+)
+
+func TestAuthLogin_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	users := []models.User{
+		{Username: models.SystemUsername, Password: "system123", Name: "System User", Role: models.RoleSystem},
+		{Username: "editor", Password: "password123", Name: "Editor", Role: models.RoleUser},
+	}
+	for i := range users {
+		if err := db.Create(&users[i]).Error; err != nil {
+			t.Fatalf("Failed to create user: %v", err)
+		}
+	}
+
+	authService := service.NewAuthService(db, auth.NewTokenManager(&config.JWTConfig{
+		Secret:             "secret",
+		AccessTokenExpiry:  15 * time.Minute,
+		RefreshTokenExpiry: 24 * time.Hour,
+	}))
+	ctx := context.Background()
+
+	t.Run("User logs in", func(t *testing.T) {
+		pair, err := authService.Login(ctx, &request.LoginRequest{Username: "editor", Password: "password123"})
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if pair.AccessToken == "" || pair.RefreshToken == "" {
+			t.Error("Expected an access and a refresh token")
+		}
+	})
+
+	testCases := []struct {
+		name     string
+		username string
+		password string
+	}{
+		{name: "System account cannot log in", username: models.SystemUsername, password: "system123"},
+		{name: "Wrong password", username: "editor", password: "wrong"},
+		{name: "Unknown user", username: "nobody", password: "password123"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := authService.Login(ctx, &request.LoginRequest{Username: tc.username, Password: tc.password})
+			if !errors.Is(err, service.ErrInvalidCredentials) {
+				t.Errorf("Expected ErrInvalidCredentials, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/auth"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/pkg/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TokenPair is an access token together with the refresh token that can renew it
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    time.Duration
+}
+
+// AuthService handles login, token refresh/rotation and logout
+type AuthService struct {
+	db     *gorm.DB
+	tokens *auth.TokenManager
+}
+
+// NewAuthService creates a new AuthService
+func NewAuthService(db *gorm.DB, tokens *auth.TokenManager) *AuthService {
+	return &AuthService{db: db, tokens: tokens}
+}
+
+// Login verifies the credentials and starts a new token family. The SYSTEM
+// account only stamps audit fields and can never log in.
+func (s *AuthService) Login(ctx context.Context, req *request.LoginRequest) (*TokenPair, error) {
+	db := s.db.WithContext(ctx)
+
+	var user models.User
+	err := db.Where("username = ?", req.Username).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Spend the time of a password check, so that timing does not tell unknown users apart
+		utils.VerifyDummyPassword(req.Password)
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !user.CheckPassword(req.Password) || user.Role == models.RoleSystem {
+		return nil, ErrInvalidCredentials
+	}
+
+	// Upgrade hashes created with an older bcrypt cost while the plaintext is at hand
+	if utils.NeedsRehash(user.Password) {
+		user.Password = req.Password
+		if err := db.Select("password").Save(&user).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	familyID, err := auth.NewFamilyID()
+	if err != nil {
+		return nil, err
+	}
+
+	var pair *TokenPair
+	err = db.Transaction(func(tx *gorm.DB) error {
+		pair, _, err = s.issueTokenPair(tx, &user, familyID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pair, nil
+}
+
+// Refresh exchanges a refresh token for a new token pair. Each refresh token can
+// be used once; presenting an already rotated token revokes its whole family,
+// since it means the token was stolen or replayed.
+func (s *AuthService) Refresh(ctx context.Context, req *request.RefreshTokenRequest) (*TokenPair, error) {
+	var pair *TokenPair
+	var reusedBy uint
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current models.RefreshToken
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ?", auth.HashToken(req.RefreshToken)).
+			First(&current).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUnauthenticated
+		}
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if current.RevokedAt != nil {
+			reusedBy = current.UserID
+			return nil
+		}
+		if !current.IsActive(now) {
+			return ErrUnauthenticated
+		}
+
+		var user models.User
+		if err := tx.First(&user, current.UserID).Error; err != nil {
+			return translateUnauthenticated(err)
+		}
+
+		var next *models.RefreshToken
+		pair, next, err = s.issueTokenPair(tx, &user, current.FamilyID)
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&current).Updates(map[string]interface{}{
+			"revoked_at":  now,
+			"replaced_by": next.ID,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if reusedBy != 0 {
+		if err := s.revokeFamilyOf(ctx, reusedBy, req.RefreshToken); err != nil {
+			return nil, err
+		}
+		return nil, ErrUnauthenticated
+	}
+
+	return pair, nil
+}
+
+// Logout revokes the access token identified by claims and, when given, the
+// refresh token family it belongs to
+func (s *AuthService) Logout(ctx context.Context, claims *auth.AccessClaims, req *request.LogoutRequest) error {
+	userID, err := claims.UserID()
+	if err != nil {
+		return ErrUnauthenticated
+	}
+
+	db := s.db.WithContext(ctx)
+
+	revoked := models.RevokedToken{
+		JTI:       claims.ID,
+		UserID:    userID,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}
+	err = db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "jti"}}, DoNothing: true}).
+		Create(&revoked).Error
+	if err != nil {
+		return err
+	}
+
+	if req.RefreshToken != "" {
+		if err := s.revokeFamilyOf(ctx, userID, req.RefreshToken); err != nil {
+			return err
+		}
+	}
+
+	// Housekeeping: revocation entries are useless once the access token has expired
+	return db.Unscoped().Where("expires_at < ?", time.Now()).Delete(&models.RevokedToken{}).Error
+}
+
+// Authenticate validates an access token, checks the logout revocation list and
+// loads the user it was issued to
+func (s *AuthService) Authenticate(ctx context.Context, tokenString string) (*models.User, *auth.AccessClaims, error) {
+	claims, err := s.tokens.ParseAccessToken(tokenString)
+	if err != nil {
+		return nil, nil, ErrUnauthenticated
+	}
+
+	userID, err := claims.UserID()
+	if err != nil {
+		return nil, nil, ErrUnauthenticated
+	}
+
+	db := s.db.WithContext(ctx)
+
+	var revokedCount int64
+	if err := db.Model(&models.RevokedToken{}).Where("jti = ?", claims.ID).Count(&revokedCount).Error; err != nil {
+		return nil, nil, err
+	}
+	if revokedCount > 0 {
+		return nil, nil, ErrUnauthenticated
+	}
+
+	var user models.User
+	if err := db.First(&user, userID).Error; err != nil {
+		return nil, nil, translateUnauthenticated(err)
+	}
+
+	return &user, claims, nil
+}
+
+// issueTokenPair signs an access token and persists a new refresh token in the given family
+func (s *AuthService) issueTokenPair(tx *gorm.DB, user *models.User, familyID string) (*TokenPair, *models.RefreshToken, error) {
+	accessToken, _, err := s.tokens.IssueAccessToken(user.ID, string(user.Role))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	refreshToken, refreshHash, err := s.tokens.NewRefreshToken()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	record := models.RefreshToken{
+		UserID:    user.ID,
+		TokenHash: refreshHash,
+		FamilyID:  familyID,
+		ExpiresAt: time.Now().Add(s.tokens.RefreshTokenExpiry()),
+	}
+	if err := tx.Create(&record).Error; err != nil {
+		return nil, nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    s.tokens.AccessTokenExpiry(),
+	}, &record, nil
+}
+
+// revokeFamilyOf revokes every still active refresh token of userID sharing a family with refreshToken
+func (s *AuthService) revokeFamilyOf(ctx context.Context, userID uint, refreshToken string) error {
+	db := s.db.WithContext(ctx)
+
+	var token models.RefreshToken
+	err := db.Where("token_hash = ? AND user_id = ?", auth.HashToken(refreshToken), userID).
+		First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return db.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", token.FamilyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// translateUnauthenticated maps gorm.ErrRecordNotFound to ErrUnauthenticated
+func translateUnauthenticated(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrUnauthenticated
+	}
+	return err
+}
@@ -0,0 +1,92 @@
+//go:build integration
+// +build integration
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/internal/service"
+	"github.com/Wilson1510/klampis-pim-go/internal/testutil"
+)
+
+func TestCatalogGetBySlug_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testUser := models.User{Username: "testuser", Password: "password123", Name: "Test User", Role: models.RoleUser}
+	if err := db.Create(&testUser).Error; err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	base := func(active bool) models.Base {
+		return models.Base{CreatedBy: testUser.ID, UpdatedBy: testUser.ID, IsActive: active}
+	}
+
+	category := models.Category{Base: base(true), Name: "Laptops"}
+	db.Create(&category)
+	product := models.Product{Base: base(true), Name: "ROG Strix", CategoryID: category.ID}
+	db.Create(&product)
+	hidden := models.Product{Base: base(false), Name: "Prototype", CategoryID: category.ID}
+	db.Create(&hidden)
+	sku := models.Sku{Base: base(true), Name: "ROG Strix 16GB", SkuNumber: "ROG-16", Price: 100, ProductID: product.ID}
+	db.Create(&sku)
+	inactiveSku := models.Sku{Base: base(false), Name: "ROG Strix 8GB", SkuNumber: "ROG-8", Price: 80, ProductID: product.ID}
+	db.Create(&inactiveSku)
+
+	// Rename both products so their old slugs end up in the history
+	product.Name = "ROG Strix G15"
+	db.Save(&product)
+	hidden.Name = "Prototype X"
+	db.Save(&hidden)
+	sku.Name = "ROG Strix G15 16GB"
+	db.Save(&sku)
+
+	productService := service.NewProductService(db, service.NewCursorCodec("secret"))
+	skuService := service.NewSkuService(db, service.NewCursorCodec("secret"))
+	ctx := context.Background()
+
+	t.Run("Current product slug", func(t *testing.T) {
+		found, err := productService.GetBySlug(ctx, "rog-strix-g15")
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if found.ID != product.ID {
+			t.Errorf("Expected product %d, got %d", product.ID, found.ID)
+		}
+		if len(found.Skus) != 1 || found.Skus[0].ID != sku.ID {
+			t.Errorf("Expected only the active SKU, got %+v", found.Skus)
+		}
+	})
+
+	t.Run("Old product slug", func(t *testing.T) {
+		_, err := productService.GetBySlug(ctx, "rog-strix")
+
+		var movedErr *service.MovedError
+		if !errors.As(err, &movedErr) || movedErr.MovedTo != "rog-strix-g15" {
+			t.Errorf("Expected a move to 'rog-strix-g15', got %v", err)
+		}
+	})
+
+	t.Run("Old SKU slug", func(t *testing.T) {
+		_, err := skuService.GetBySlug(ctx, "rog-strix-16gb")
+
+		var movedErr *service.MovedError
+		if !errors.As(err, &movedErr) || movedErr.MovedTo != "rog-strix-g15-16gb" {
+			t.Errorf("Expected a move to 'rog-strix-g15-16gb', got %v", err)
+		}
+	})
+
+	t.Run("Inactive records are not found", func(t *testing.T) {
+		for _, slug := range []string{"prototype", "prototype-x"} {
+			if _, err := productService.GetBySlug(ctx, slug); !errors.Is(err, service.ErrNotFound) {
+				t.Errorf("Expected ErrNotFound for '%s', got %v", slug, err)
+			}
+		}
+		if _, err := skuService.GetBySlug(ctx, "rog-strix-8gb"); !errors.Is(err, service.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound for an inactive SKU, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,158 @@
+//go:build integration
+// +build integration
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/internal/service"
+	"github.com/Wilson1510/klampis-pim-go/internal/testutil"
+	"gorm.io/gorm"
+)
+
+func TestCategoryMove_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testUser := models.User{Username: "testuser", Password: "password123", Name: "Test User", Role: models.RoleUser}
+	if err := db.Create(&testUser).Error; err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	base := models.Base{CreatedBy: testUser.ID, UpdatedBy: testUser.ID}
+	createCategory := func(name string, parentID *uint) models.Category {
+		category := models.Category{Base: base, Name: name, ParentID: parentID}
+		if err := db.Create(&category).Error; err != nil {
+			t.Fatalf("Failed to create category: %v", err)
+		}
+		return category
+	}
+	createProduct := func(name string, categoryID uint) models.Product {
+		product := models.Product{Base: base, Name: name, CategoryID: categoryID}
+		if err := db.Create(&product).Error; err != nil {
+			t.Fatalf("Failed to create product: %v", err)
+		}
+		return product
+	}
+	categoryOf := func(product models.Product) uint {
+		var stored models.Product
+		db.First(&stored, product.ID)
+		return stored.CategoryID
+	}
+
+	// Electronics > Computers > Laptops, Office
+	electronics := createCategory("Electronics", nil)
+	computers := createCategory("Computers", &electronics.ID)
+	laptops := createCategory("Laptops", &computers.ID)
+	office := createCategory("Office", nil)
+	desktop := createProduct("Desktop", computers.ID)
+	notebook := createProduct("Notebook", laptops.ID)
+
+	categoryService := service.NewCategoryService(db, service.NewCursorCodec("secret"))
+	ctx := context.Background()
+
+	var validationErr *service.ValidationError
+	var conflictErr *service.ConflictError
+
+	t.Run("Move below a descendant is rejected", func(t *testing.T) {
+		_, err := categoryService.Move(ctx, electronics.ID, &request.MoveCategoryRequest{ParentID: &laptops.ID})
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("Expected ValidationError, got %v", err)
+		}
+
+		_, err = categoryService.Update(ctx, computers.ID, &request.UpdateCategoryRequest{ParentID: &computers.ID})
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected ValidationError on update, got %v", err)
+		}
+	})
+
+	t.Run("Failed move leaves products untouched", func(t *testing.T) {
+		_, err := categoryService.Move(ctx, computers.ID, &request.MoveCategoryRequest{
+			ParentID: &laptops.ID, Products: request.ProductsParent,
+		})
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("Expected ValidationError, got %v", err)
+		}
+		if categoryOf(desktop) != computers.ID {
+			t.Errorf("Expected Desktop to stay in Computers after the rollback")
+		}
+	})
+
+	t.Run("Move subtree and leave products with the old parent", func(t *testing.T) {
+		moved, err := categoryService.Move(ctx, computers.ID, &request.MoveCategoryRequest{
+			ParentID: &office.ID, Products: request.ProductsParent,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		if moved.ParentID == nil || *moved.ParentID != office.ID {
+			t.Errorf("Expected Computers below Office, got %v", moved.ParentID)
+		}
+		if categoryOf(desktop) != electronics.ID {
+			t.Errorf("Expected Desktop to stay with Electronics, got category %d", categoryOf(desktop))
+		}
+
+		var storedLaptops models.Category
+		db.First(&storedLaptops, laptops.ID)
+		if storedLaptops.ParentID == nil || *storedLaptops.ParentID != computers.ID {
+			t.Errorf("Expected Laptops to move along below Computers")
+		}
+		if categoryOf(notebook) != laptops.ID {
+			t.Errorf("Expected Notebook to stay in Laptops, got category %d", categoryOf(notebook))
+		}
+	})
+
+	t.Run("Move to the root level", func(t *testing.T) {
+		moved, err := categoryService.Move(ctx, computers.ID, &request.MoveCategoryRequest{})
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if moved.ParentID != nil {
+			t.Errorf("Expected Computers at the root level, got parent %d", *moved.ParentID)
+		}
+
+		_, err = categoryService.Move(ctx, computers.ID, &request.MoveCategoryRequest{Products: request.ProductsParent})
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected ValidationError for a root without parent, got %v", err)
+		}
+	})
+
+	t.Run("Delete moves products to the target category", func(t *testing.T) {
+		err := categoryService.Delete(ctx, laptops.ID, &request.DeleteCategoryRequest{})
+		if !errors.As(err, &conflictErr) {
+			t.Fatalf("Expected ConflictError while Laptops has products, got %v", err)
+		}
+
+		err = categoryService.Delete(ctx, laptops.ID, &request.DeleteCategoryRequest{
+			Products: request.ProductsCategory, TargetCategoryID: &office.ID,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if categoryOf(notebook) != office.ID {
+			t.Errorf("Expected Notebook in Office, got category %d", categoryOf(notebook))
+		}
+		if err := db.First(&models.Category{}, laptops.ID).Error; !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Errorf("Expected Laptops to be deleted, got %v", err)
+		}
+	})
+
+	t.Run("Delete with children is rejected before moving products", func(t *testing.T) {
+		createCategory("Tablets", &office.ID)
+
+		err := categoryService.Delete(ctx, office.ID, &request.DeleteCategoryRequest{
+			Products: request.ProductsCategory, TargetCategoryID: &electronics.ID,
+		})
+		if !errors.As(err, &conflictErr) {
+			t.Fatalf("Expected ConflictError, got %v", err)
+		}
+		if categoryOf(notebook) != office.ID {
+			t.Errorf("Expected Notebook to stay in Office after the rollback")
+		}
+	})
+}
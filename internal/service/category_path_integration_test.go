@@ -0,0 +1,133 @@
+//go:build integration
+// +build integration
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/internal/service"
+	"github.com/Wilson1510/klampis-pim-go/internal/testutil"
+)
+
+func TestCategoryGetByPath_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testUser := models.User{Username: "testuser", Password: "password123", Name: "Test User", Role: models.RoleUser}
+	if err := db.Create(&testUser).Error; err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	createCategory := func(name string, parentID *uint, active bool) models.Category {
+		category := models.Category{
+			Base: models.Base{CreatedBy: testUser.ID, UpdatedBy: testUser.ID, IsActive: active},
+			Name: name, ParentID: parentID,
+		}
+		if err := db.Create(&category).Error; err != nil {
+			t.Fatalf("Failed to create category: %v", err)
+		}
+		return category
+	}
+
+	// Electronics > Laptops > Gaming, Electronics > Archive (inactive) > Old
+	electronics := createCategory("Electronics", nil, true)
+	laptops := createCategory("Laptops", &electronics.ID, true)
+	gaming := createCategory("Gaming", &laptops.ID, true)
+	archive := createCategory("Archive", &electronics.ID, false)
+	createCategory("Old", &archive.ID, true)
+
+	categoryService := service.NewCategoryService(db, service.NewCursorCodec("secret"))
+	ctx := context.Background()
+
+	t.Run("Resolve a nested path", func(t *testing.T) {
+		category, ancestors, err := categoryService.GetByPath(ctx, "/electronics/laptops/gaming/")
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if category.ID != gaming.ID {
+			t.Errorf("Expected Gaming, got %s", category.Name)
+		}
+		if len(ancestors) != 2 || ancestors[0].ID != electronics.ID || ancestors[1].ID != laptops.ID {
+			t.Errorf("Expected Electronics then Laptops as ancestors, got %+v", ancestors)
+		}
+	})
+
+	testCases := []struct {
+		name string
+		path string
+	}{
+		{name: "Unknown path", path: "electronics/phones"},
+		{name: "Inactive category", path: "electronics/archive"},
+		{name: "Below an inactive ancestor", path: "electronics/archive/old"},
+		{name: "Empty path", path: "/"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := categoryService.GetByPath(ctx, tc.path)
+			if !errors.Is(err, service.ErrNotFound) {
+				t.Errorf("Expected ErrNotFound, got %v", err)
+			}
+		})
+	}
+
+	t.Run("Outdated paths redirect to the current path", func(t *testing.T) {
+		laptops.Name = "Notebooks"
+		if err := db.Save(&laptops).Error; err != nil {
+			t.Fatalf("Failed to rename category: %v", err)
+		}
+
+		for _, path := range []string{"electronics/laptops", "electronics/laptops/gaming", "gaming"} {
+			_, _, err := categoryService.GetByPath(ctx, path)
+
+			var movedErr *service.MovedError
+			if !errors.As(err, &movedErr) {
+				t.Errorf("Expected MovedError for '%s', got %v", path, err)
+				continue
+			}
+			expected := "electronics/notebooks"
+			if path != "electronics/laptops" {
+				expected = "electronics/notebooks/gaming"
+			}
+			if movedErr.MovedTo != expected {
+				t.Errorf("Expected '%s' to move to '%s', got '%s'", path, expected, movedErr.MovedTo)
+			}
+		}
+
+		// The old slug of an inactive category is not revealed
+		if _, _, err := categoryService.GetByPath(ctx, "old"); !errors.Is(err, service.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound below an inactive ancestor, got %v", err)
+		}
+	})
+
+	t.Run("Categories created through the service can be published", func(t *testing.T) {
+		active, inactive := true, false
+		phones, err := categoryService.Create(ctx, &request.CreateCategoryRequest{Name: "Phones", IsActive: &active})
+		if err != nil {
+			t.Fatalf("Failed to create category: %v", err)
+		}
+		android, err := categoryService.Create(ctx, &request.CreateCategoryRequest{Name: "Android", ParentID: &phones.ID, IsActive: &inactive})
+		if err != nil {
+			t.Fatalf("Failed to create category: %v", err)
+		}
+
+		if _, _, err := categoryService.GetByPath(ctx, "phones/android"); !errors.Is(err, service.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound before publishing, got %v", err)
+		}
+
+		if _, err := categoryService.Update(ctx, android.ID, &request.UpdateCategoryRequest{IsActive: &active}); err != nil {
+			t.Fatalf("Failed to publish category: %v", err)
+		}
+		category, ancestors, err := categoryService.GetByPath(ctx, "phones/android")
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if category.ID != android.ID || len(ancestors) != 1 || ancestors[0].ID != phones.ID {
+			t.Errorf("Expected Android below Phones, got %s with ancestors %+v", category.Name, ancestors)
+		}
+	})
+}
@@ -0,0 +1,537 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/pkg/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// categoryListSpec whitelists what category listings may filter and sort on
+var categoryListSpec = listSpec{
+	filterFields: []string{"id", "name", "slug", "parent_id", "is_active", "sequence", "created_at", "updated_at"},
+	sortFields: sortColumns("id", "name", "slug", "parent_id", "is_active", "sequence", "created_at", "updated_at").with(map[string]string{
+		"parent.name": "(SELECT parent.name FROM categories parent WHERE parent.id = categories.parent_id)",
+	}),
+}
+
+// CategoryService contains the business logic for categories
+type CategoryService struct {
+	db      *gorm.DB
+	cursors *CursorCodec
+}
+
+// NewCategoryService creates a new CategoryService
+func NewCategoryService(db *gorm.DB, cursors *CursorCodec) *CategoryService {
+	return &CategoryService{db: db, cursors: cursors}
+}
+
+// List returns a page of categories matching the request filters
+func (s *CategoryService) List(ctx context.Context, req *request.GetCategoriesRequest) ([]models.Category, *PageInfo, error) {
+	query := s.db.WithContext(ctx).Model(&models.Category{})
+
+	if req.Name != "" {
+		query = query.Where("name ILIKE ?", "%"+req.Name+"%")
+	}
+	if req.RootOnly {
+		query = query.Where("parent_id IS NULL")
+	} else if req.ParentID != nil {
+		query = query.Where("parent_id = ?", *req.ParentID)
+	}
+
+	var categories []models.Category
+	page, err := paginate(query, &req.PaginationRequest, categoryListSpec, s.cursors, &categories)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return categories, page, nil
+}
+
+// Get returns a single category with its parent preloaded
+func (s *CategoryService) Get(ctx context.Context, id uint) (*models.Category, error) {
+	var category models.Category
+	err := s.db.WithContext(ctx).Preload("Parent").First(&category, id).Error
+	if err != nil {
+		return nil, translateNotFound(err)
+	}
+
+	return &category, nil
+}
+
+// GetByPath resolves a full slug path such as "electronics/laptops" to a category
+// for the public catalog, together with its ancestors root first. The category and
+// every ancestor must be active; otherwise the path is reported as not found. An
+// outdated path of a renamed or moved category returns a MovedError.
+func (s *CategoryService) GetByPath(ctx context.Context, fullPath string) (*models.Category, []models.Category, error) {
+	db := s.db.WithContext(ctx)
+
+	fullPath = strings.Trim(fullPath, "/")
+	if fullPath == "" {
+		return nil, nil, ErrNotFound
+	}
+
+	var category models.Category
+	err := db.Preload("Parent").Where("full_path = ?", fullPath).First(&category).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, resolveMovedCategoryPath(db, fullPath)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ancestors, err := publishedAncestors(db, &category)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &category, ancestors, nil
+}
+
+// resolveMovedCategoryPath finds the category an outdated path points to by its
+// last slug, which identifies a category on its own, and returns a MovedError with
+// the current full path
+func resolveMovedCategoryPath(db *gorm.DB, fullPath string) error {
+	slug := fullPath[strings.LastIndex(fullPath, "/")+1:]
+
+	var category models.Category
+	err := db.Where("slug = ?", slug).First(&category).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		history, historyErr := models.FindSlugHistory(db, "categories", slug)
+		if historyErr != nil {
+			return translateNotFound(historyErr)
+		}
+		err = db.First(&category, history.EntityID).Error
+	}
+	if err != nil {
+		return translateNotFound(err)
+	}
+
+	if _, err := publishedAncestors(db, &category); err != nil {
+		return err
+	}
+	return &MovedError{MovedTo: category.FullPath}
+}
+
+// publishedAncestors loads the ancestors of a category shown in the public catalog.
+// A category that is inactive or below an inactive or deleted ancestor is not found.
+func publishedAncestors(db *gorm.DB, category *models.Category) ([]models.Category, error) {
+	if !category.IsActive {
+		return nil, ErrNotFound
+	}
+
+	ancestors, err := category.Ancestors(db)
+	if err != nil {
+		return nil, err
+	}
+	// A soft-deleted ancestor is missing from the result
+	if len(ancestors) != len(category.AncestorIDs()) {
+		return nil, ErrNotFound
+	}
+	for _, ancestor := range ancestors {
+		if !ancestor.IsActive {
+			return nil, ErrNotFound
+		}
+	}
+
+	return ancestors, nil
+}
+
+// Create creates a new category
+func (s *CategoryService) Create(ctx context.Context, req *request.CreateCategoryRequest) (*models.Category, error) {
+	db := s.db.WithContext(ctx)
+
+	if req.ParentID != nil {
+		if err := ensureExists(db, &models.Category{}, *req.ParentID, "parent_id"); err != nil {
+			return nil, err
+		}
+	}
+
+	category := models.Category{
+		Name:        req.Name,
+		Description: req.Description,
+		ParentID:    req.ParentID,
+	}
+	if req.IsActive != nil {
+		category.IsActive = *req.IsActive
+	}
+	err := utils.RetryOnSlugConflict(IsSlugConflict, func() error {
+		return db.Create(&category).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, category.ID)
+}
+
+// Update applies the non-nil fields of the request to an existing category
+func (s *CategoryService) Update(ctx context.Context, id uint, req *request.UpdateCategoryRequest) (*models.Category, error) {
+	err := utils.RetryOnSlugConflict(IsSlugConflict, func() error {
+		return s.update(ctx, id, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, id)
+}
+
+// update runs Update in a single transaction
+func (s *CategoryService) update(ctx context.Context, id uint, req *request.UpdateCategoryRequest) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if req.ParentID != nil {
+			if err := lockCategoryHierarchy(tx); err != nil {
+				return err
+			}
+		}
+
+		var category models.Category
+		if err := tx.First(&category, id).Error; err != nil {
+			return translateNotFound(err)
+		}
+
+		if req.Name != nil {
+			category.Name = *req.Name
+		}
+		if req.Description != nil {
+			category.Description = *req.Description
+		}
+		if req.IsActive != nil {
+			category.IsActive = *req.IsActive
+		}
+		if req.SlugPinned != nil {
+			category.SlugPinned = *req.SlugPinned
+		}
+		if req.ParentID != nil {
+			if err := ensureExists(tx, &models.Category{}, *req.ParentID, "parent_id"); err != nil {
+				return err
+			}
+			category.ParentID = req.ParentID
+		}
+
+		return saveCategory(tx, &category)
+	})
+}
+
+// Move re-parents a category together with its whole subtree and optionally
+// hands its own products to another category, all in one transaction
+func (s *CategoryService) Move(ctx context.Context, id uint, req *request.MoveCategoryRequest) (*models.Category, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockCategoryHierarchy(tx); err != nil {
+			return err
+		}
+
+		var category models.Category
+		if err := tx.First(&category, id).Error; err != nil {
+			return translateNotFound(err)
+		}
+		if req.ParentID != nil {
+			if err := ensureExists(tx, &models.Category{}, *req.ParentID, "parent_id"); err != nil {
+				return err
+			}
+		}
+
+		if req.Products != "" && req.Products != request.ProductsKeep {
+			if err := reassignProducts(tx, &category, req.Products, req.TargetCategoryID); err != nil {
+				return err
+			}
+		}
+
+		category.ParentID = req.ParentID
+		return saveCategory(tx, &category)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, id)
+}
+
+// Delete removes a category without children. Its products are moved as requested;
+// a category that still has products is only deleted when a placement is given.
+func (s *CategoryService) Delete(ctx context.Context, id uint, req *request.DeleteCategoryRequest) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockCategoryHierarchy(tx); err != nil {
+			return err
+		}
+
+		var category models.Category
+		if err := tx.First(&category, id).Error; err != nil {
+			return translateNotFound(err)
+		}
+
+		if req.Products != "" {
+			if err := reassignProducts(tx, &category, req.Products, req.TargetCategoryID); err != nil {
+				return err
+			}
+		}
+
+		var childCount, productCount int64
+		if err := tx.Model(&models.Category{}).Where("parent_id = ?", id).Count(&childCount).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Product{}).Where("category_id = ?", id).Count(&productCount).Error; err != nil {
+			return err
+		}
+		if childCount > 0 || productCount > 0 {
+			return NewConflictError(map[string]int64{
+				"children": childCount,
+				"products": productCount,
+			}, "category %d still has children or products", id)
+		}
+
+		return tx.Delete(&category).Error
+	})
+}
+
+// categoryHierarchyLock is the advisory lock key serializing parent changes. Two
+// concurrent moves could otherwise each pass the cycle check and form a loop together.
+const categoryHierarchyLock = 0x6b6c6d70 // "klmp"
+
+// lockCategoryHierarchy takes the hierarchy lock until the transaction ends
+func lockCategoryHierarchy(tx *gorm.DB) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(?)", categoryHierarchyLock).Error
+}
+
+// saveCategory saves a category, reporting a parent that would create a cycle
+// as a validation error on parent_id
+func saveCategory(tx *gorm.DB, category *models.Category) error {
+	err := tx.Omit(clause.Associations).Save(category).Error
+	if errors.Is(err, models.ErrCategoryCycle) {
+		return NewValidationError(map[string]string{"parent_id": "cannot be the category itself or one of its descendants"},
+			"category %d cannot be placed below itself", category.ID)
+	}
+	return err
+}
+
+// reassignProducts moves the products assigned directly to category to its
+// previous parent or to the target category
+func reassignProducts(tx *gorm.DB, category *models.Category, placement string, targetID *uint) error {
+	var destination uint
+	switch placement {
+	case request.ProductsParent:
+		if category.ParentID == nil {
+			return NewValidationError(map[string]string{"products": "category has no parent to receive its products"},
+				"category %d has no parent", category.ID)
+		}
+		destination = *category.ParentID
+	case request.ProductsCategory:
+		if targetID == nil {
+			return NewValidationError(map[string]string{"target_category_id": "is required"}, "target_category_id is required")
+		}
+		if *targetID == category.ID {
+			return NewValidationError(map[string]string{"target_category_id": "must be another category"},
+				"target_category_id must differ from category %d", category.ID)
+		}
+		if err := ensureExists(tx, &models.Category{}, *targetID, "target_category_id"); err != nil {
+			return err
+		}
+		destination = *targetID
+	default:
+		return NewValidationError(map[string]string{"products": "unknown placement"}, "unknown product placement %q", placement)
+	}
+
+	return tx.Model(&models.Product{}).Where("category_id = ?", category.ID).Update("category_id", destination).Error
+}
+
+// Children returns a page of the direct children of a category
+func (s *CategoryService) Children(ctx context.Context, id uint, pagination *request.PaginationRequest) ([]models.Category, *PageInfo, error) {
+	db := s.db.WithContext(ctx)
+
+	if err := ensureExists(db, &models.Category{}, id, ""); err != nil {
+		return nil, nil, err
+	}
+
+	var children []models.Category
+	page, err := paginate(db.Model(&models.Category{}).Where("parent_id = ?", id), pagination, categoryListSpec, s.cursors, &children)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return children, page, nil
+}
+
+// Products returns a page of the products assigned to a category and, when
+// requested, to any of its subcategories
+func (s *CategoryService) Products(ctx context.Context, id uint, req *request.GetCategoryProductsRequest) ([]models.Product, *PageInfo, error) {
+	db := s.db.WithContext(ctx)
+
+	var category models.Category
+	if err := db.Select("id", "path").First(&category, id).Error; err != nil {
+		return nil, nil, translateNotFound(err)
+	}
+
+	query := db.Model(&models.Product{}).Where("category_id = ?", id)
+	if req.IncludeSubcategories {
+		subtree, err := category.SubtreeIDs(db)
+		if err != nil {
+			return nil, nil, err
+		}
+		query = db.Model(&models.Product{}).Where("category_id IN (?)", subtree)
+	}
+
+	var products []models.Product
+	page, err := paginate(query, &req.PaginationRequest, productListSpec, s.cursors, &products)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return products, page, nil
+}
+
+// categoryTreeRow is a category loaded by the tree query together with its depth below the roots
+type categoryTreeRow struct {
+	models.Category
+	Depth int
+}
+
+// Tree loads the category hierarchy with a single recursive query and assembles
+// it in memory. Each root is returned with its Children filled in, ordered by
+// sequence and name. With IncludeProductCount, counts maps every returned
+// category to the number of products in it and all of its descendants.
+func (s *CategoryService) Tree(ctx context.Context, req *request.GetCategoryTreeRequest) ([]models.Category, map[uint]int64, error) {
+	db := s.db.WithContext(ctx)
+
+	if req.RootID != nil {
+		if err := ensureExists(db, &models.Category{}, *req.RootID, ""); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	cte, vars := categoryTreeCTE(req)
+
+	var rows []categoryTreeRow
+	err := db.Raw(cte+`
+		SELECT categories.*, tree.depth
+		FROM tree JOIN categories ON categories.id = tree.id
+		ORDER BY tree.depth, categories.sequence, categories.name, categories.id`, vars...).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var counts map[uint]int64
+	if req.IncludeProductCount {
+		if counts, err = s.treeProductCounts(db, req); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return buildCategoryTree(rows), counts, nil
+}
+
+// categoryTreeCTE returns the recursive "tree" CTE selecting the ids and depths
+// of the requested categories. The path array stops the walk at any cycle in parent_id.
+func categoryTreeCTE(req *request.GetCategoryTreeRequest) (string, []interface{}) {
+	var vars []interface{}
+
+	seed := "c.parent_id IS NULL"
+	if req.RootID != nil {
+		seed = "c.id = ?"
+		vars = append(vars, *req.RootID)
+	}
+	active := ""
+	if req.IsActive != nil {
+		active = " AND c.is_active = ?"
+		vars = append(vars, *req.IsActive)
+	}
+
+	step := "c.deleted_at IS NULL AND NOT c.id = ANY(tree.path)"
+	if req.IsActive != nil {
+		step += " AND c.is_active = ?"
+		vars = append(vars, *req.IsActive)
+	}
+	if req.MaxDepth != nil {
+		step += " AND tree.depth < ?"
+		vars = append(vars, *req.MaxDepth)
+	}
+
+	return `
+		WITH RECURSIVE tree AS (
+			SELECT c.id, 0 AS depth, ARRAY[c.id] AS path
+			FROM categories c
+			WHERE c.deleted_at IS NULL AND ` + seed + active + `
+			UNION ALL
+			SELECT c.id, tree.depth + 1, tree.path || c.id
+			FROM categories c JOIN tree ON c.parent_id = tree.id
+			WHERE ` + step + `
+		)`, vars
+}
+
+// treeProductCounts counts the products below every category of the tree, including
+// categories deeper than max_depth. With is_active set, only matching categories and
+// products are counted.
+func (s *CategoryService) treeProductCounts(db *gorm.DB, req *request.GetCategoryTreeRequest) (map[uint]int64, error) {
+	cte, vars := categoryTreeCTE(req)
+
+	activeCategory, activeProduct := "", ""
+	if req.IsActive != nil {
+		activeCategory = " AND c.is_active = ?"
+		activeProduct = " AND p.is_active = ?"
+		vars = append(vars, *req.IsActive, *req.IsActive)
+	}
+
+	var results []struct {
+		CategoryID   uint
+		ProductCount int64
+	}
+	err := db.Raw(cte+`,
+		subtree AS (
+			SELECT tree.id AS ancestor_id, tree.id AS category_id, ARRAY[tree.id] AS path
+			FROM tree
+			UNION ALL
+			SELECT subtree.ancestor_id, c.id, subtree.path || c.id
+			FROM categories c JOIN subtree ON c.parent_id = subtree.category_id
+			WHERE c.deleted_at IS NULL AND NOT c.id = ANY(subtree.path)`+activeCategory+`
+		)
+		SELECT subtree.ancestor_id AS category_id, COUNT(p.id) AS product_count
+		FROM subtree JOIN products p ON p.category_id = subtree.category_id AND p.deleted_at IS NULL`+activeProduct+`
+		GROUP BY subtree.ancestor_id`, vars...).
+		Scan(&results).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint]int64, len(results))
+	for _, result := range results {
+		counts[result.CategoryID] = result.ProductCount
+	}
+	return counts, nil
+}
+
+// buildCategoryTree nests rows ordered by depth under their parents and returns the roots
+func buildCategoryTree(rows []categoryTreeRow) []models.Category {
+	byID := make(map[uint]models.Category, len(rows))
+	childIDs := make(map[uint][]uint, len(rows))
+	var rootIDs []uint
+
+	for _, row := range rows {
+		byID[row.ID] = row.Category
+		if row.Depth == 0 {
+			rootIDs = append(rootIDs, row.ID)
+		} else {
+			childIDs[*row.ParentID] = append(childIDs[*row.ParentID], row.ID)
+		}
+	}
+
+	var build func(id uint) models.Category
+	build = func(id uint) models.Category {
+		category := byID[id]
+		for _, childID := range childIDs[id] {
+			category.Children = append(category.Children, build(childID))
+		}
+		return category
+	}
+
+	roots := make([]models.Category, len(rootIDs))
+	for i, id := range rootIDs {
+		roots[i] = build(id)
+	}
+	return roots
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestBuildCategoryTree(t *testing.T) {
+	// Setup: rows come back ordered by depth, then sequence and name
+	row := func(id uint, parentID *uint, depth int) categoryTreeRow {
+		return categoryTreeRow{
+			Category: models.Category{Base: models.Base{Model: gorm.Model{ID: id}}, ParentID: parentID},
+			Depth:    depth,
+		}
+	}
+	one, two, three := uint(1), uint(2), uint(3)
+	rows := []categoryTreeRow{
+		row(1, nil, 0),
+		row(5, nil, 0),
+		row(3, &one, 1),
+		row(2, &one, 1),
+		row(4, &three, 2),
+		// A subtree root requested with root_id still has a parent
+		row(6, &two, 0),
+	}
+
+	// Execute
+	roots := buildCategoryTree(rows)
+
+	// Assert
+	if assert.Len(t, roots, 3) {
+		assert.Equal(t, []uint{1, 5, 6}, []uint{roots[0].ID, roots[1].ID, roots[2].ID})
+		if assert.Len(t, roots[0].Children, 2) {
+			assert.Equal(t, uint(3), roots[0].Children[0].ID)
+			assert.Equal(t, uint(2), roots[0].Children[1].ID)
+			if assert.Len(t, roots[0].Children[0].Children, 1) {
+				assert.Equal(t, uint(4), roots[0].Children[0].Children[0].ID)
+			}
+		}
+		assert.Empty(t, roots[1].Children)
+	}
+	assert.Empty(t, buildCategoryTree(nil))
+}
+
+func TestCategoryTreeCTE(t *testing.T) {
+	rootID, maxDepth, active := uint(7), 2, true
+
+	testCases := []struct {
+		name         string
+		req          request.GetCategoryTreeRequest
+		contains     []string
+		notContains  []string
+		expectedVars []interface{}
+	}{
+		{
+			name:         "Every root without limits",
+			req:          request.GetCategoryTreeRequest{},
+			contains:     []string{"c.parent_id IS NULL", "NOT c.id = ANY(tree.path)"},
+			notContains:  []string{"tree.depth <", "is_active"},
+			expectedVars: nil,
+		},
+		{
+			name:         "Subtree with depth limit and active filter",
+			req:          request.GetCategoryTreeRequest{RootID: &rootID, MaxDepth: &maxDepth, IsActive: &active},
+			contains:     []string{"c.id = ?", "c.is_active = ?", "tree.depth < ?"},
+			notContains:  []string{"c.parent_id IS NULL"},
+			expectedVars: []interface{}{rootID, true, true, 2},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			sql, vars := categoryTreeCTE(&tc.req)
+
+			for _, fragment := range tc.contains {
+				assert.Contains(t, sql, fragment)
+			}
+			for _, fragment := range tc.notContains {
+				assert.NotContains(t, sql, fragment)
+			}
+			assert.Equal(t, tc.expectedVars, vars)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+//go:build integration
+// +build integration
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/internal/service"
+	"github.com/Wilson1510/klampis-pim-go/internal/testutil"
+)
+
+func TestCategoryTree_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testUser := models.User{Username: "testuser", Password: "password123", Name: "Test User", Role: models.RoleUser}
+	if err := db.Create(&testUser).Error; err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	base := func(active bool) models.Base {
+		return models.Base{CreatedBy: testUser.ID, UpdatedBy: testUser.ID, IsActive: active}
+	}
+	createCategory := func(name string, parent *models.Category, active bool) models.Category {
+		category := models.Category{Base: base(active), Name: name}
+		if parent != nil {
+			category.ParentID = &parent.ID
+		}
+		if err := db.Create(&category).Error; err != nil {
+			t.Fatalf("Failed to create category: %v", err)
+		}
+		return category
+	}
+	createProducts := func(category models.Category, count int) {
+		for i := 0; i < count; i++ {
+			product := models.Product{Base: base(true), Name: category.Name + " product", CategoryID: category.ID}
+			if err := db.Create(&product).Error; err != nil {
+				t.Fatalf("Failed to create product: %v", err)
+			}
+		}
+	}
+
+	// Electronics > Computers > Laptops, Electronics > Phones (inactive), Books
+	electronics := createCategory("Electronics", nil, true)
+	computers := createCategory("Computers", &electronics, true)
+	laptops := createCategory("Laptops", &computers, true)
+	phones := createCategory("Phones", &electronics, false)
+	books := createCategory("Books", nil, true)
+	createProducts(electronics, 1)
+	createProducts(laptops, 3)
+	createProducts(phones, 2)
+
+	categoryService := service.NewCategoryService(db, service.NewCursorCodec("secret"))
+	ctx := context.Background()
+
+	t.Run("Full tree with rolled up counts", func(t *testing.T) {
+		roots, counts, err := categoryService.Tree(ctx, &request.GetCategoryTreeRequest{IncludeProductCount: true})
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		if len(roots) != 2 || roots[0].ID != books.ID || roots[1].ID != electronics.ID {
+			t.Fatalf("Expected roots Books and Electronics, got %+v", roots)
+		}
+		if len(roots[1].Children) != 2 || len(roots[1].Children[0].Children) != 1 {
+			t.Errorf("Expected Electronics to hold Computers > Laptops and Phones, got %+v", roots[1].Children)
+		}
+
+		expected := map[uint]int64{electronics.ID: 6, computers.ID: 3, laptops.ID: 3, phones.ID: 2}
+		for id, count := range expected {
+			if counts[id] != count {
+				t.Errorf("Expected category %d to count %d products, got %d", id, count, counts[id])
+			}
+		}
+		if counts[books.ID] != 0 {
+			t.Errorf("Expected Books to count no products, got %d", counts[books.ID])
+		}
+	})
+
+	t.Run("Depth limit keeps counts of deeper levels", func(t *testing.T) {
+		maxDepth := 0
+		roots, counts, err := categoryService.Tree(ctx, &request.GetCategoryTreeRequest{
+			MaxDepth: &maxDepth, IncludeProductCount: true,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		for _, root := range roots {
+			if len(root.Children) != 0 {
+				t.Errorf("Expected no children at depth 0, got %d under %s", len(root.Children), root.Name)
+			}
+		}
+		if counts[electronics.ID] != 6 {
+			t.Errorf("Expected Electronics to count 6 products, got %d", counts[electronics.ID])
+		}
+	})
+
+	t.Run("Subtree of active categories", func(t *testing.T) {
+		active := true
+		roots, counts, err := categoryService.Tree(ctx, &request.GetCategoryTreeRequest{
+			RootID: &electronics.ID, IsActive: &active, IncludeProductCount: true,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		if len(roots) != 1 || roots[0].ID != electronics.ID {
+			t.Fatalf("Expected Electronics as the only root, got %+v", roots)
+		}
+		if len(roots[0].Children) != 1 || roots[0].Children[0].ID != computers.ID {
+			t.Errorf("Expected inactive Phones to be excluded, got %+v", roots[0].Children)
+		}
+		if counts[electronics.ID] != 4 {
+			t.Errorf("Expected Electronics to count 4 products without Phones, got %d", counts[electronics.ID])
+		}
+	})
+
+	t.Run("Unknown root", func(t *testing.T) {
+		missing := uint(999999)
+		_, _, err := categoryService.Tree(ctx, &request.GetCategoryTreeRequest{RootID: &missing})
+		if !errors.Is(err, service.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+	})
+}
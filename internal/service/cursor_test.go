@@ -0,0 +1,51 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorCodec(t *testing.T) {
+	codec := NewCursorCodec("secret")
+	payload := cursorPayload{Sort: "-price,id", Values: []string{"19.99", "42"}, Before: true}
+
+	t.Run("Round trip", func(t *testing.T) {
+		cursor, err := codec.encode(payload)
+		require.NoError(t, err)
+
+		decoded, err := codec.decode(cursor)
+		require.NoError(t, err)
+		assert.Equal(t, payload, *decoded)
+	})
+
+	t.Run("Tampered body is rejected", func(t *testing.T) {
+		cursor, err := codec.encode(payload)
+		require.NoError(t, err)
+
+		forged, err := codec.encode(cursorPayload{Sort: "-price,id", Values: []string{"0", "1"}})
+		require.NoError(t, err)
+		body, _, _ := strings.Cut(forged, ".")
+		_, signature, _ := strings.Cut(cursor, ".")
+
+		_, err = codec.decode(body + "." + signature)
+		assert.ErrorIs(t, err, errInvalidCursor)
+	})
+
+	t.Run("Other secret is rejected", func(t *testing.T) {
+		cursor, err := NewCursorCodec("other").encode(payload)
+		require.NoError(t, err)
+
+		_, err = codec.decode(cursor)
+		assert.ErrorIs(t, err, errInvalidCursor)
+	})
+
+	t.Run("Garbage is rejected", func(t *testing.T) {
+		for _, cursor := range []string{"", "abc", "abc.def", "!!.!!"} {
+			_, err := codec.decode(cursor)
+			assert.ErrorIs(t, err, errInvalidCursor, cursor)
+		}
+	})
+}
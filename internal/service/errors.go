@@ -0,0 +1,121 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// ErrNotFound is returned when the requested record does not exist
+var ErrNotFound = errors.New("resource not found")
+
+// ErrInvalidCredentials is returned when a username/password pair does not match
+var ErrInvalidCredentials = errors.New("invalid username or password")
+
+// ErrUnauthenticated is returned when a token is missing, invalid, expired or revoked
+var ErrUnauthenticated = errors.New("authentication required")
+
+// ValidationError is returned when a request is well-formed but violates a business rule
+type ValidationError struct {
+	Message string
+	Details interface{}
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// NewValidationError creates a ValidationError with optional details
+func NewValidationError(details interface{}, format string, args ...interface{}) *ValidationError {
+	return &ValidationError{
+		Message: fmt.Sprintf(format, args...),
+		Details: details,
+	}
+}
+
+// ConflictError is returned when an operation clashes with the current state of the data
+type ConflictError struct {
+	Message string
+	Details interface{}
+}
+
+// Error implements the error interface
+func (e *ConflictError) Error() string {
+	return e.Message
+}
+
+// NewConflictError creates a ConflictError with optional details
+func NewConflictError(details interface{}, format string, args ...interface{}) *ConflictError {
+	return &ConflictError{
+		Message: fmt.Sprintf(format, args...),
+		Details: details,
+	}
+}
+
+// MovedError is returned by public lookups when the requested slug or path was
+// replaced by a rename. MovedTo is the current slug or path to redirect to.
+type MovedError struct {
+	MovedTo string
+}
+
+// Error implements the error interface
+func (e *MovedError) Error() string {
+	return "resource moved to " + e.MovedTo
+}
+
+// IsSlugConflict reports whether err is a Postgres unique violation on a slug
+// index, i.e. a concurrent write saved the same slug first
+func IsSlugConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && strings.Contains(pgErr.ConstraintName, "slug")
+}
+
+// translateNotFound maps gorm.ErrRecordNotFound to ErrNotFound
+func translateNotFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// ensureExists checks that a record of the given model exists. When field is set,
+// a missing record is reported as a validation error on that field instead of ErrNotFound.
+func ensureExists(db *gorm.DB, model interface{}, id uint, field string) error {
+	var count int64
+	if err := db.Model(model).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	if field == "" {
+		return ErrNotFound
+	}
+	return NewValidationError(map[string]string{field: "does not exist"}, "%s %d does not exist", field, id)
+}
+
+// resolveMovedSlug looks up a slug that is not in use anymore in the slug history
+// of table. An active record that used it is reported as a MovedError naming its
+// current slug; anything else is not found.
+func resolveMovedSlug(db *gorm.DB, model interface{}, table, slug string) error {
+	history, err := models.FindSlugHistory(db, table, slug)
+	if err != nil {
+		return translateNotFound(err)
+	}
+
+	var current []string
+	err = db.Model(model).Where("id = ? AND is_active = ?", history.EntityID, true).Limit(1).Pluck("slug", &current).Error
+	if err != nil {
+		return err
+	}
+	if len(current) == 0 {
+		return ErrNotFound
+	}
+	return &MovedError{MovedTo: current[0]}
+}
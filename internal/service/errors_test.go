@@ -0,0 +1,31 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSlugConflict(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"Unique violation on slug", &pgconn.PgError{Code: "23505", ConstraintName: "idx_products_slug"}, true},
+		{"Wrapped unique violation", fmt.Errorf("create: %w", &pgconn.PgError{Code: "23505", ConstraintName: "idx_skus_slug"}), true},
+		{"Unique violation on other column", &pgconn.PgError{Code: "23505", ConstraintName: "idx_skus_sku_number"}, false},
+		{"Other database error", &pgconn.PgError{Code: "23503", ConstraintName: "idx_products_slug"}, false},
+		{"Not a database error", errors.New("slug taken"), false},
+		{"No error", nil, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsSlugConflict(tc.err))
+		})
+	}
+}
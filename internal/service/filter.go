@@ -0,0 +1,296 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/pkg/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// filterKind groups Go field types by how filter values are parsed and compared
+type filterKind int
+
+const (
+	filterKindText filterKind = iota
+	filterKindBool
+	filterKindInt
+	filterKindUint
+	filterKindFloat
+	filterKindTime
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// attributeFilterPrefix selects filtering by a SKU attribute value, as in filter[attr.ram][ge]=8
+const attributeFilterPrefix = "attr."
+
+// filterDateLayouts are the accepted formats for time filter values
+var filterDateLayouts = []string{time.RFC3339, "2006-01-02"}
+
+// applyFilters adds a WHERE condition for every filter. Fields must be listed in
+// allowed; values are converted to the Go type of the column in the query model.
+// All problems are reported together in a single ValidationError.
+func applyFilters(query *gorm.DB, filters []request.Filter, allowed []string) (*gorm.DB, error) {
+	if len(filters) == 0 {
+		return query, nil
+	}
+
+	stmt := &gorm.Statement{DB: query}
+	if err := stmt.Parse(query.Statement.Model); err != nil {
+		return nil, err
+	}
+
+	details := make(map[string]interface{})
+	for _, filter := range filters {
+		param := fmt.Sprintf("filter[%s][%s]", filter.Field, filter.Operator)
+
+		field := stmt.Schema.LookUpField(filter.Field)
+		if !containsString(allowed, filter.Field) || field == nil {
+			details[param] = "unknown field"
+			details["allowed_fields"] = allowed
+			continue
+		}
+
+		expr, err := filterExpression(field, filter)
+		if err != nil {
+			details[param] = err.Error()
+			continue
+		}
+		query = query.Where(expr)
+	}
+
+	if len(details) > 0 {
+		return nil, NewValidationError(details, "invalid filter parameters")
+	}
+	return query, nil
+}
+
+// applyAttributeFilters adds a condition for every filter on a SKU attribute
+// value and returns the remaining filters. A SKU matches when one of its values
+// for the attribute does. NUMBER attributes compare by base value, so a filter
+// value may be given in any unit of the same dimension, as in
+// filter[attr.storage][ge]=1 TB; DATE attributes compare as dates, and other
+// types compare the stored value (the option code for OPTION) with eq, ne or like.
+func applyAttributeFilters(query *gorm.DB, filters []request.Filter) (*gorm.DB, []request.Filter, error) {
+	var rest []request.Filter
+	details := make(map[string]interface{})
+
+	for _, filter := range filters {
+		code, ok := strings.CutPrefix(filter.Field, attributeFilterPrefix)
+		if !ok {
+			rest = append(rest, filter)
+			continue
+		}
+		param := fmt.Sprintf("filter[%s][%s]", filter.Field, filter.Operator)
+
+		var attribute models.Attribute
+		err := query.Session(&gorm.Session{NewDB: true}).Where("code = ?", code).First(&attribute).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			details[param] = fmt.Sprintf("unknown attribute code %q", code)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		expr, err := attributeFilterExpression(&attribute, filter)
+		if err != nil {
+			details[param] = err.Error()
+			continue
+		}
+		query = query.Where(expr)
+	}
+
+	if len(details) > 0 {
+		return nil, nil, NewValidationError(details, "invalid filter parameters")
+	}
+	return query, rest, nil
+}
+
+// attributeFilterExpression builds the condition for a single filter on the values of attribute
+func attributeFilterExpression(attribute *models.Attribute, filter request.Filter) (clause.Expression, error) {
+	condition, value := "v.value", interface{}(filter.Value)
+
+	switch attribute.DataType {
+	case models.DataTypeNumber, models.DataTypeDate:
+		if filter.Operator == request.FilterLike {
+			return nil, fmt.Errorf("operator like is not supported on %s attributes", attribute.DataType)
+		}
+		parsed, err := attribute.ParseValue(filter.Value)
+		if err != nil {
+			return nil, err
+		}
+		if date, ok := parsed.(time.Time); ok {
+			value = date.Format(utils.DateLayout)
+			break
+		}
+		base := attribute.BaseValue(filter.Value)
+		if base == nil {
+			return nil, fmt.Errorf("must be a finite number")
+		}
+		condition, value = "v.base_value", *base
+
+	default:
+		switch filter.Operator {
+		case request.FilterLike:
+			condition = "v.value ILIKE ? ESCAPE '\\'"
+			value = "%" + escapeLike(filter.Value) + "%"
+		case request.FilterEq, request.FilterNe:
+		default:
+			return nil, fmt.Errorf("operator %s is only supported on NUMBER and DATE attributes", filter.Operator)
+		}
+	}
+
+	if filter.Operator != request.FilterLike {
+		condition += " " + attributeFilterOperators[filter.Operator] + " ?"
+	}
+	return clause.Expr{
+		SQL: "EXISTS (SELECT 1 FROM sku_attribute_values v" +
+			" WHERE v.sku_id = skus.id AND v.attribute_id = ? AND v.deleted_at IS NULL AND " + condition + ")",
+		Vars: []interface{}{attribute.ID, value},
+	}, nil
+}
+
+// attributeFilterOperators maps comparison operators to SQL
+var attributeFilterOperators = map[request.FilterOperator]string{
+	request.FilterEq: "=",
+	request.FilterNe: "<>",
+	request.FilterGt: ">",
+	request.FilterLt: "<",
+	request.FilterGe: ">=",
+	request.FilterLe: "<=",
+}
+
+// kindOf classifies a field by its Go type. The column type is not used since
+// explicit tags such as type:decimal(15,2) replace GORM's generic data type.
+func kindOf(field *schema.Field) filterKind {
+	fieldType := field.IndirectFieldType
+	if fieldType.ConvertibleTo(timeType) {
+		return filterKindTime
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Bool:
+		return filterKindBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return filterKindInt
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return filterKindUint
+	case reflect.Float32, reflect.Float64:
+		return filterKindFloat
+	default:
+		return filterKindText
+	}
+}
+
+// filterExpression builds the condition for a single filter on field
+func filterExpression(field *schema.Field, filter request.Filter) (clause.Expression, error) {
+	column := clause.Column{Table: clause.CurrentTable, Name: field.DBName}
+	kind := kindOf(field)
+
+	if filter.Operator == request.FilterLike {
+		if kind != filterKindText {
+			return nil, fmt.Errorf("operator like is only supported on text fields")
+		}
+		return clause.Expr{
+			SQL:  "? ILIKE ? ESCAPE '\\'",
+			Vars: []interface{}{column, "%" + escapeLike(filter.Value) + "%"},
+		}, nil
+	}
+
+	// null matches missing values on non-text columns, e.g. filter[parent_id]=null
+	if filter.Value == "null" && kind != filterKindText {
+		switch filter.Operator {
+		case request.FilterEq:
+			return clause.Expr{SQL: "? IS NULL", Vars: []interface{}{column}}, nil
+		case request.FilterNe:
+			return clause.Expr{SQL: "? IS NOT NULL", Vars: []interface{}{column}}, nil
+		}
+	}
+
+	value, err := coerceFilterValue(kind, filter.Value)
+	if err != nil {
+		return nil, err
+	}
+	if kind == filterKindBool && filter.Operator != request.FilterEq && filter.Operator != request.FilterNe {
+		return nil, fmt.Errorf("operator %s is not supported on boolean fields", filter.Operator)
+	}
+
+	switch filter.Operator {
+	case request.FilterNe:
+		return clause.Neq{Column: column, Value: value}, nil
+	case request.FilterGt:
+		return clause.Gt{Column: column, Value: value}, nil
+	case request.FilterLt:
+		return clause.Lt{Column: column, Value: value}, nil
+	case request.FilterGe:
+		return clause.Gte{Column: column, Value: value}, nil
+	case request.FilterLe:
+		return clause.Lte{Column: column, Value: value}, nil
+	default:
+		return clause.Eq{Column: column, Value: value}, nil
+	}
+}
+
+// coerceFilterValue converts a raw query value to the type of the column
+func coerceFilterValue(kind filterKind, raw string) (interface{}, error) {
+	switch kind {
+	case filterKindBool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("must be a boolean")
+		}
+		return value, nil
+	case filterKindInt:
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("must be an integer")
+		}
+		return value, nil
+	case filterKindUint:
+		value, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("must be a non-negative integer")
+		}
+		return value, nil
+	case filterKindFloat:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("must be a number")
+		}
+		return value, nil
+	case filterKindTime:
+		for _, layout := range filterDateLayouts {
+			if value, err := time.Parse(layout, raw); err == nil {
+				return value, nil
+			}
+		}
+		return nil, fmt.Errorf("must be a date (YYYY-MM-DD) or RFC3339 timestamp")
+	default:
+		return raw, nil
+	}
+}
+
+// escapeLike escapes the LIKE wildcards in a user supplied search term
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
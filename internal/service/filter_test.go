@@ -0,0 +1,195 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// newDryRunDB opens a GORM handle that builds SQL without touching a database
+func newDryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+	return db
+}
+
+func TestApplyFilters(t *testing.T) {
+	db := newDryRunDB(t)
+
+	testCases := []struct {
+		name         string
+		filters      []request.Filter
+		expectedSQL  string
+		expectedVars []interface{}
+	}{
+		{
+			name:         "Equality on text",
+			filters:      []request.Filter{{Field: "sku_number", Operator: request.FilterEq, Value: "LAP-001"}},
+			expectedSQL:  `"skus"."sku_number" = $1`,
+			expectedVars: []interface{}{"LAP-001"},
+		},
+		{
+			name:         "Number range is coerced",
+			filters:      []request.Filter{{Field: "price", Operator: request.FilterGe, Value: "99.5"}},
+			expectedSQL:  `"skus"."price" >= $1`,
+			expectedVars: []interface{}{99.5},
+		},
+		{
+			name:         "Foreign key is coerced to uint",
+			filters:      []request.Filter{{Field: "product_id", Operator: request.FilterNe, Value: "3"}},
+			expectedSQL:  `"skus"."product_id" <> $1`,
+			expectedVars: []interface{}{uint64(3)},
+		},
+		{
+			name:         "Boolean",
+			filters:      []request.Filter{{Field: "is_active", Operator: request.FilterEq, Value: "true"}},
+			expectedSQL:  `"skus"."is_active" = $1`,
+			expectedVars: []interface{}{true},
+		},
+		{
+			name:         "Like escapes wildcards",
+			filters:      []request.Filter{{Field: "name", Operator: request.FilterLike, Value: "50%_off"}},
+			expectedSQL:  `"skus"."name" ILIKE $1 ESCAPE '\'`,
+			expectedVars: []interface{}{`%50\%\_off%`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := applyFilters(db.Model(&models.Sku{}), tc.filters, skuListSpec.filterFields)
+			require.NoError(t, err)
+
+			var skus []models.Sku
+			stmt := query.Find(&skus).Statement
+
+			assert.Contains(t, stmt.SQL.String(), tc.expectedSQL)
+			assert.Equal(t, tc.expectedVars, stmt.Vars)
+		})
+	}
+}
+
+func TestApplyFiltersNull(t *testing.T) {
+	db := newDryRunDB(t)
+
+	filters := []request.Filter{{Field: "parent_id", Operator: request.FilterEq, Value: "null"}}
+	query, err := applyFilters(db.Model(&models.Category{}), filters, categoryListSpec.filterFields)
+	require.NoError(t, err)
+
+	var categories []models.Category
+	stmt := query.Find(&categories).Statement
+
+	assert.Contains(t, stmt.SQL.String(), `"categories"."parent_id" IS NULL`)
+}
+
+func TestApplyFiltersErrors(t *testing.T) {
+	db := newDryRunDB(t)
+
+	testCases := []struct {
+		name        string
+		filter      request.Filter
+		expectedKey string
+	}{
+		{name: "Unknown field", filter: request.Filter{Field: "cost", Operator: request.FilterEq, Value: "1"}, expectedKey: "filter[cost][eq]"},
+		{name: "Field outside whitelist", filter: request.Filter{Field: "description", Operator: request.FilterEq, Value: "x"}, expectedKey: "filter[description][eq]"},
+		{name: "Struct field name is not accepted", filter: request.Filter{Field: "Price", Operator: request.FilterEq, Value: "1"}, expectedKey: "filter[Price][eq]"},
+		{name: "Invalid number", filter: request.Filter{Field: "price", Operator: request.FilterGt, Value: "cheap"}, expectedKey: "filter[price][gt]"},
+		{name: "Like on number", filter: request.Filter{Field: "price", Operator: request.FilterLike, Value: "1"}, expectedKey: "filter[price][like]"},
+		{name: "Range on boolean", filter: request.Filter{Field: "is_active", Operator: request.FilterGt, Value: "true"}, expectedKey: "filter[is_active][gt]"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := applyFilters(db.Model(&models.Sku{}), []request.Filter{tc.filter}, skuListSpec.filterFields)
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			details := validationErr.Details.(map[string]interface{})
+			assert.Contains(t, details, tc.expectedKey)
+		})
+	}
+
+	t.Run("Unknown field lists allowed fields", func(t *testing.T) {
+		filters := []request.Filter{{Field: "cost", Operator: request.FilterEq, Value: "1"}}
+		_, err := applyFilters(db.Model(&models.Sku{}), filters, skuListSpec.filterFields)
+
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, skuListSpec.filterFields, validationErr.Details.(map[string]interface{})["allowed_fields"])
+	})
+}
+
+func TestAttributeFilterExpression(t *testing.T) {
+	storage := &models.Attribute{Base: models.Base{Model: gorm.Model{ID: 4}}, DataType: models.DataTypeNumber, UOM: "GB"}
+	released := &models.Attribute{Base: models.Base{Model: gorm.Model{ID: 5}}, DataType: models.DataTypeDate}
+	color := &models.Attribute{Base: models.Base{Model: gorm.Model{ID: 6}}, DataType: models.DataTypeText}
+
+	testCases := []struct {
+		name          string
+		attribute     *models.Attribute
+		filter        request.Filter
+		expectedSQL   string
+		expectedValue interface{}
+	}{
+		{
+			name:          "Number in another unit compares by base value",
+			attribute:     storage,
+			filter:        request.Filter{Field: "attr.storage", Operator: request.FilterGe, Value: "1 TB"},
+			expectedSQL:   "v.base_value >= ?",
+			expectedValue: 1e12,
+		},
+		{
+			name:          "Date compares in ISO form",
+			attribute:     released,
+			filter:        request.Filter{Field: "attr.released", Operator: request.FilterLt, Value: "Mar 4, 2024"},
+			expectedSQL:   "v.value < ?",
+			expectedValue: "2024-03-04",
+		},
+		{
+			name:          "Text like escapes wildcards",
+			attribute:     color,
+			filter:        request.Filter{Field: "attr.color", Operator: request.FilterLike, Value: "50%"},
+			expectedSQL:   `v.value ILIKE ? ESCAPE '\'`,
+			expectedValue: `%50\%%`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Execute
+			expr, err := attributeFilterExpression(tc.attribute, tc.filter)
+
+			// Assert
+			require.NoError(t, err)
+			condition := expr.(clause.Expr)
+			assert.Contains(t, condition.SQL, tc.expectedSQL)
+			assert.Equal(t, []interface{}{tc.attribute.ID, tc.expectedValue}, condition.Vars)
+		})
+	}
+
+	t.Run("Invalid filters", func(t *testing.T) {
+		invalid := []struct {
+			attribute *models.Attribute
+			filter    request.Filter
+		}{
+			{storage, request.Filter{Operator: request.FilterGe, Value: "3 GHz"}},
+			{storage, request.Filter{Operator: request.FilterLike, Value: "1"}},
+			{released, request.Filter{Operator: request.FilterEq, Value: "03/04/2024"}},
+			{color, request.Filter{Operator: request.FilterGt, Value: "red"}},
+		}
+		for _, tc := range invalid {
+			_, err := attributeFilterExpression(tc.attribute, tc.filter)
+			assert.Error(t, err, "%s %s %s", tc.attribute.DataType, tc.filter.Operator, tc.filter.Value)
+		}
+	})
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// listSpec describes what the list endpoints of a resource accept
+type listSpec struct {
+	// filterFields whitelists the columns usable in ?filter[field][op]=value
+	filterFields []string
+	// sortFields whitelists the keys usable in ?sort= and what they order by
+	sortFields sortExpressions
+	// attributeSort allows sorting by SKU attribute values with attr.<code>
+	attributeSort bool
+	// attributeFilter allows filtering by SKU attribute values with filter[attr.<code>]
+	attributeFilter bool
+}
+
+// PageInfo describes the page returned by a listing. Total is only counted for
+// offset pagination; the cursors are only set for cursor pagination.
+type PageInfo struct {
+	Total      int64
+	NextCursor string
+	PrevCursor string
+}
+
+// paginate applies the request filters and sort, then loads the requested page
+// into dest, which must be a pointer to a slice of the query model. Offset or
+// keyset pagination is used depending on the request.
+func paginate(query *gorm.DB, pagination *request.PaginationRequest, spec listSpec, cursors *CursorCodec, dest interface{}) (*PageInfo, error) {
+	filters := pagination.Filters
+	if spec.attributeFilter {
+		var err error
+		query, filters, err = applyAttributeFilters(query, filters)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	query, err := applyFilters(query, filters, spec.filterFields)
+	if err != nil {
+		return nil, err
+	}
+
+	if pagination.IsCursorMode() {
+		return paginateByCursor(query, pagination, spec, cursors, dest)
+	}
+
+	// Count ignores the ORDER BY, so invalid sort keys are rejected before any query runs
+	query, err = applySort(query, pagination.GetSortKeys(), spec)
+	if err != nil {
+		return nil, err
+	}
+	query = query.Session(&gorm.Session{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	err = query.
+		Offset(pagination.GetOffset()).
+		Limit(pagination.GetLimit()).
+		Find(dest).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &PageInfo{Total: total}, nil
+}
+
+// paginateByCursor loads the page after (or before) the row encoded in the
+// request cursor using a keyset condition instead of OFFSET, so pages stay fast
+// and stable while rows are inserted. Rows are not counted.
+func paginateByCursor(query *gorm.DB, pagination *request.PaginationRequest, spec listSpec, cursors *CursorCodec, dest interface{}) (*PageInfo, error) {
+	stmt := &gorm.Statement{DB: query}
+	if err := stmt.Parse(query.Statement.Model); err != nil {
+		return nil, err
+	}
+
+	keys, fields, err := keysetKeys(stmt.Schema, pagination.GetSortKeys(), spec)
+	if err != nil {
+		return nil, err
+	}
+	sortSignature := canonicalSort(keys)
+
+	var boundary *cursorPayload
+	if pagination.Cursor != "" {
+		boundary, err = cursors.decode(pagination.Cursor)
+		if err != nil || boundary.Sort != sortSignature || len(boundary.Values) != len(keys) {
+			return nil, NewValidationError(map[string]string{"cursor": "is invalid or was issued for a different sort"},
+				"invalid cursor")
+		}
+	}
+
+	// Walking backwards reverses every sort key; the rows are flipped back afterwards
+	backward := boundary != nil && boundary.Before
+	order := keys
+	if backward {
+		order = make([]request.SortKey, len(keys))
+		for i, key := range keys {
+			order[i] = request.SortKey{Field: key.Field, Desc: !key.Desc}
+		}
+	}
+
+	if boundary != nil {
+		condition, err := keysetCondition(fields, order, boundary.Values)
+		if err != nil {
+			return nil, err
+		}
+		query = query.Where(condition)
+	}
+
+	query, err = applySort(query, order, spec)
+	if err != nil {
+		return nil, err
+	}
+
+	// One extra row tells whether another page follows in this direction
+	limit := pagination.GetLimit()
+	if err := query.Limit(limit + 1).Find(dest).Error; err != nil {
+		return nil, err
+	}
+
+	rows := reflect.ValueOf(dest).Elem()
+	hasMore := rows.Len() > limit
+	if hasMore {
+		rows.Set(rows.Slice(0, limit))
+	}
+	if backward {
+		swap := reflect.Swapper(rows.Interface())
+		for i, j := 0, rows.Len()-1; i < j; i, j = i+1, j-1 {
+			swap(i, j)
+		}
+	}
+
+	info := &PageInfo{}
+	encode := func(values []string, before bool) (string, error) {
+		return cursors.encode(cursorPayload{Sort: sortSignature, Values: values, Before: before})
+	}
+
+	// An empty page past the boundary can still lead back to it
+	if rows.Len() == 0 {
+		if boundary == nil {
+			return info, nil
+		}
+		cursor, err := encode(boundary.Values, !backward)
+		if backward {
+			info.NextCursor = cursor
+		} else {
+			info.PrevCursor = cursor
+		}
+		return info, err
+	}
+
+	first := cursorValues(stmt, fields, rows.Index(0))
+	last := cursorValues(stmt, fields, rows.Index(rows.Len()-1))
+	if backward || hasMore {
+		if info.NextCursor, err = encode(last, false); err != nil {
+			return nil, err
+		}
+	}
+	if (backward && hasMore) || (!backward && boundary != nil) {
+		if info.PrevCursor, err = encode(first, true); err != nil {
+			return nil, err
+		}
+	}
+
+	return info, nil
+}
+
+// keysetKeys validates the sort keys for cursor pagination and appends the
+// primary key as a tie-breaker. Only non-nullable columns of the model can be
+// used, since related values and NULLs cannot be compared row by row.
+func keysetKeys(model *schema.Schema, keys []request.SortKey, spec listSpec) ([]request.SortKey, []*schema.Field, error) {
+	var fields []*schema.Field
+	var result []request.SortKey
+	details := make(map[string]interface{})
+	hasID := false
+
+	for _, key := range keys {
+		expr, isSortField := spec.sortFields[key.Field]
+		field := model.LookUpField(key.Field)
+		switch {
+		case !isSortField:
+			details[key.Field] = "unknown sort field"
+		case !isColumnExpr(expr) || field == nil:
+			details[key.Field] = "cannot be used with cursor pagination"
+		case field.FieldType.Kind() == reflect.Ptr:
+			details[key.Field] = "is nullable and cannot be used with cursor pagination"
+		default:
+			fields = append(fields, field)
+			result = append(result, key)
+			hasID = hasID || key.Field == "id"
+		}
+	}
+
+	if len(details) > 0 {
+		details["allowed_sort_fields"] = spec.sortFields.keys()
+		return nil, nil, NewValidationError(details, "invalid sort parameters")
+	}
+
+	if !hasID {
+		fields = append(fields, model.LookUpField("id"))
+		result = append(result, request.SortKey{Field: "id"})
+	}
+	return result, fields, nil
+}
+
+// keysetCondition matches the rows after the boundary values in the given order:
+// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ..., with < for descending keys
+func keysetCondition(fields []*schema.Field, order []request.SortKey, rawValues []string) (clause.Expression, error) {
+	values := make([]interface{}, len(fields))
+	for i, field := range fields {
+		value, err := coerceFilterValue(kindOf(field), rawValues[i])
+		if err != nil {
+			return nil, NewValidationError(map[string]string{"cursor": "is invalid"}, "invalid cursor")
+		}
+		values[i] = value
+	}
+
+	var branches []clause.Expression
+	for i := range fields {
+		var terms []clause.Expression
+		for j := 0; j < i; j++ {
+			terms = append(terms, clause.Eq{Column: keysetColumn(fields[j]), Value: values[j]})
+		}
+		if order[i].Desc {
+			terms = append(terms, clause.Lt{Column: keysetColumn(fields[i]), Value: values[i]})
+		} else {
+			terms = append(terms, clause.Gt{Column: keysetColumn(fields[i]), Value: values[i]})
+		}
+		branches = append(branches, clause.AndConditions{Exprs: terms})
+	}
+
+	return clause.OrConditions{Exprs: branches}, nil
+}
+
+// keysetColumn qualifies a sort column with the listed table
+func keysetColumn(field *schema.Field) clause.Column {
+	return clause.Column{Table: clause.CurrentTable, Name: field.DBName}
+}
+
+// cursorValues reads the sort key values of a loaded row as strings
+func cursorValues(stmt *gorm.Statement, fields []*schema.Field, row reflect.Value) []string {
+	row = reflect.Indirect(row)
+	values := make([]string, len(fields))
+	for i, field := range fields {
+		value, _ := field.ValueOf(stmt.Context, row)
+		if t, ok := value.(time.Time); ok {
+			values[i] = t.Format(time.RFC3339Nano)
+			continue
+		}
+		values[i] = fmt.Sprint(value)
+	}
+	return values
+}
+
+// canonicalSort renders sort keys as a ?sort= value, identifying the order a cursor belongs to
+func canonicalSort(keys []request.SortKey) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		if key.Desc {
+			parts[i] = "-" + key.Field
+		} else {
+			parts[i] = key.Field
+		}
+	}
+	return strings.Join(parts, ",")
+}
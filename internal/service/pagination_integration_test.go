@@ -0,0 +1,97 @@
+//go:build integration
+// +build integration
+
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/internal/service"
+	"github.com/Wilson1510/klampis-pim-go/internal/testutil"
+)
+
+func TestCursorPagination_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testUser := models.User{Username: "testuser", Password: "password123", Name: "Test User", Role: models.RoleUser}
+	if err := db.Create(&testUser).Error; err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	base := models.Base{CreatedBy: testUser.ID, UpdatedBy: testUser.ID}
+
+	// Five categories sharing two names so the id tie-breaker matters
+	for i := 1; i <= 5; i++ {
+		category := models.Category{Base: base, Name: fmt.Sprintf("Category %d", i%2)}
+		if err := db.Create(&category).Error; err != nil {
+			t.Fatalf("Failed to create category: %v", err)
+		}
+	}
+
+	categoryService := service.NewCategoryService(db, service.NewCursorCodec("secret"))
+	list := func(cursor string) ([]models.Category, *service.PageInfo) {
+		req := request.GetCategoriesRequest{PaginationRequest: request.PaginationRequest{
+			Pagination: "cursor", Cursor: cursor, Sort: "-name", Limit: 2,
+		}}
+		categories, page, err := categoryService.List(context.Background(), &req)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		return categories, page
+	}
+	ids := func(categories []models.Category) []uint {
+		result := make([]uint, len(categories))
+		for i, category := range categories {
+			result[i] = category.ID
+		}
+		return result
+	}
+
+	var all []models.Category
+	db.Order("name DESC, id ASC").Find(&all)
+
+	// Walk forwards through every page
+	var seen []uint
+	var pages []*service.PageInfo
+	cursor := ""
+	for {
+		categories, page := list(cursor)
+		seen = append(seen, ids(categories)...)
+		pages = append(pages, page)
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	if fmt.Sprint(seen) != fmt.Sprint(ids(all)) {
+		t.Errorf("Expected forward walk %v, got %v", ids(all), seen)
+	}
+	if len(pages) != 3 {
+		t.Fatalf("Expected 3 pages, got %d", len(pages))
+	}
+	if pages[0].PrevCursor != "" {
+		t.Errorf("Expected no prev_cursor on the first page")
+	}
+
+	// Walking back from the last page returns the middle page
+	categories, page := list(pages[2].PrevCursor)
+	if fmt.Sprint(ids(categories)) != fmt.Sprint(ids(all[2:4])) {
+		t.Errorf("Expected previous page %v, got %v", ids(all[2:4]), ids(categories))
+	}
+	if page.NextCursor == "" || page.PrevCursor == "" {
+		t.Errorf("Expected both cursors on the middle page, got %+v", page)
+	}
+
+	// A cursor cannot be replayed with another sort
+	req := request.GetCategoriesRequest{PaginationRequest: request.PaginationRequest{
+		Cursor: pages[0].NextCursor, Sort: "name",
+	}}
+	if _, _, err := categoryService.List(context.Background(), &req); err == nil {
+		t.Errorf("Expected an error for a cursor issued for another sort")
+	}
+}
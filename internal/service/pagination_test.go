@@ -0,0 +1,75 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// parseSchema returns the GORM schema of model
+func parseSchema(t *testing.T, db *gorm.DB, model interface{}) *gorm.Statement {
+	t.Helper()
+
+	stmt := &gorm.Statement{DB: db}
+	require.NoError(t, stmt.Parse(model))
+	return stmt
+}
+
+func TestKeysetKeys(t *testing.T) {
+	db := newDryRunDB(t)
+	stmt := parseSchema(t, db, &models.Category{})
+
+	t.Run("Appends id tie-breaker", func(t *testing.T) {
+		keys, fields, err := keysetKeys(stmt.Schema, []request.SortKey{{Field: "name", Desc: true}}, categoryListSpec)
+
+		require.NoError(t, err)
+		assert.Equal(t, []request.SortKey{{Field: "name", Desc: true}, {Field: "id"}}, keys)
+		assert.Equal(t, "name", fields[0].DBName)
+		assert.Equal(t, "id", fields[1].DBName)
+	})
+
+	testCases := []struct {
+		name  string
+		field string
+	}{
+		{name: "Unknown field", field: "cost"},
+		{name: "Related column", field: "parent.name"},
+		{name: "Nullable column", field: "parent_id"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := keysetKeys(stmt.Schema, []request.SortKey{{Field: tc.field}}, categoryListSpec)
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Contains(t, validationErr.Details, tc.field)
+		})
+	}
+}
+
+func TestKeysetCondition(t *testing.T) {
+	db := newDryRunDB(t)
+	stmt := parseSchema(t, db, &models.Sku{})
+	fields := []*schema.Field{stmt.Schema.LookUpField("price"), stmt.Schema.LookUpField("id")}
+	order := []request.SortKey{{Field: "price", Desc: true}, {Field: "id"}}
+
+	condition, err := keysetCondition(fields, order, []string{"19.99", "42"})
+	require.NoError(t, err)
+
+	var skus []models.Sku
+	query := db.Model(&models.Sku{}).Where(condition).Find(&skus).Statement
+
+	assert.Contains(t, query.SQL.String(),
+		`("skus"."price" < $1 OR ("skus"."price" = $2 AND "skus"."id" > $3))`)
+	assert.Equal(t, []interface{}{19.99, 19.99, uint64(42)}, query.Vars)
+
+	_, err = keysetCondition(fields, order, []string{"cheap", "42"})
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/pkg/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// productListSpec whitelists what product listings may filter and sort on
+var productListSpec = listSpec{
+	filterFields: []string{"id", "name", "slug", "category_id", "is_active", "sequence", "created_at", "updated_at"},
+	sortFields: sortColumns("id", "name", "slug", "category_id", "is_active", "sequence", "created_at", "updated_at").with(map[string]string{
+		"category.name": "(SELECT categories.name FROM categories WHERE categories.id = products.category_id)",
+	}),
+}
+
+// ProductService contains the business logic for products
+type ProductService struct {
+	db      *gorm.DB
+	cursors *CursorCodec
+}
+
+// NewProductService creates a new ProductService
+func NewProductService(db *gorm.DB, cursors *CursorCodec) *ProductService {
+	return &ProductService{db: db, cursors: cursors}
+}
+
+// List returns a page of products matching the request filters
+func (s *ProductService) List(ctx context.Context, req *request.GetProductsRequest) ([]models.Product, *PageInfo, error) {
+	query := s.db.WithContext(ctx).Model(&models.Product{}).Preload("Category")
+
+	if req.Name != "" {
+		query = query.Where("name ILIKE ?", "%"+req.Name+"%")
+	}
+	if req.CategoryID != nil {
+		query = query.Where("category_id = ?", *req.CategoryID)
+	}
+	if req.IsActive != nil {
+		query = query.Where("is_active = ?", *req.IsActive)
+	}
+
+	var products []models.Product
+	page, err := paginate(query, &req.PaginationRequest, productListSpec, s.cursors, &products)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return products, page, nil
+}
+
+// Get returns a single product with its category, images and SKUs preloaded
+func (s *ProductService) Get(ctx context.Context, id uint) (*models.Product, error) {
+	var product models.Product
+	err := s.db.WithContext(ctx).
+		Preload("Category").
+		Preload("Images", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sequence, id")
+		}).
+		Preload("Skus", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sequence, id")
+		}).
+		First(&product, id).Error
+	if err != nil {
+		return nil, translateNotFound(err)
+	}
+
+	return &product, nil
+}
+
+// GetBySlug returns an active product by slug for the public catalog, with its
+// active SKUs. A slug the product used before a rename returns a MovedError.
+func (s *ProductService) GetBySlug(ctx context.Context, slug string) (*models.Product, error) {
+	db := s.db.WithContext(ctx)
+
+	var product models.Product
+	err := db.
+		Preload("Category").
+		Preload("Images", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sequence, id")
+		}).
+		Preload("Skus", func(db *gorm.DB) *gorm.DB {
+			return db.Where("is_active = ?", true).Order("sequence, id")
+		}).
+		Where("slug = ? AND is_active = ?", slug, true).
+		First(&product).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, resolveMovedSlug(db, &models.Product{}, "products", slug)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &product, nil
+}
+
+// Create creates a new product
+func (s *ProductService) Create(ctx context.Context, req *request.CreateProductRequest) (*models.Product, error) {
+	db := s.db.WithContext(ctx)
+
+	if err := ensureExists(db, &models.Category{}, req.CategoryID, "category_id"); err != nil {
+		return nil, err
+	}
+
+	product := models.Product{
+		Name:        req.Name,
+		Description: req.Description,
+		CategoryID:  req.CategoryID,
+	}
+	if req.IsActive != nil {
+		product.IsActive = *req.IsActive
+	}
+	err := utils.RetryOnSlugConflict(IsSlugConflict, func() error {
+		return db.Create(&product).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, product.ID)
+}
+
+// Update applies the non-nil fields of the request to an existing product
+func (s *ProductService) Update(ctx context.Context, id uint, req *request.UpdateProductRequest) (*models.Product, error) {
+	db := s.db.WithContext(ctx)
+
+	var product models.Product
+	if err := db.First(&product, id).Error; err != nil {
+		return nil, translateNotFound(err)
+	}
+
+	if req.Name != nil {
+		product.Name = *req.Name
+	}
+	if req.Description != nil {
+		product.Description = *req.Description
+	}
+	if req.CategoryID != nil {
+		if err := ensureExists(db, &models.Category{}, *req.CategoryID, "category_id"); err != nil {
+			return nil, err
+		}
+		product.CategoryID = *req.CategoryID
+	}
+	if req.IsActive != nil {
+		product.IsActive = *req.IsActive
+	}
+	if req.SlugPinned != nil {
+		product.SlugPinned = *req.SlugPinned
+	}
+
+	err := utils.RetryOnSlugConflict(IsSlugConflict, func() error {
+		return db.Omit(clause.Associations).Save(&product).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, product.ID)
+}
+
+// Delete removes a product that has no SKUs, together with its images
+func (s *ProductService) Delete(ctx context.Context, id uint) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var product models.Product
+		if err := tx.First(&product, id).Error; err != nil {
+			return translateNotFound(err)
+		}
+
+		var skuCount int64
+		if err := tx.Model(&models.Sku{}).Where("product_id = ?", id).Count(&skuCount).Error; err != nil {
+			return err
+		}
+		if skuCount > 0 {
+			return NewConflictError(map[string]int64{"skus": skuCount},
+				"product %d still has SKUs", id)
+		}
+
+		err := tx.Where("imageable_id = ? AND imageable_type = ?", id, "products").
+			Delete(&models.Image{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Delete(&product).Error
+	})
+}
+
+// Skus returns a page of the SKUs belonging to a product
+func (s *ProductService) Skus(ctx context.Context, id uint, pagination *request.PaginationRequest) ([]models.Sku, *PageInfo, error) {
+	db := s.db.WithContext(ctx)
+
+	if err := ensureExists(db, &models.Product{}, id, ""); err != nil {
+		return nil, nil, err
+	}
+
+	var skus []models.Sku
+	page, err := paginate(db.Model(&models.Sku{}).Where("product_id = ?", id), pagination, skuListSpec, s.cursors, &skus)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return skus, page, nil
+}
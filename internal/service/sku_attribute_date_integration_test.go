@@ -0,0 +1,128 @@
+//go:build integration
+// +build integration
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/internal/service"
+	"github.com/Wilson1510/klampis-pim-go/internal/testutil"
+)
+
+func TestSkuAttributeDates_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testUser := models.User{Username: "testuser", Password: "password123", Name: "Test User", Role: models.RoleUser}
+	if err := db.Create(&testUser).Error; err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	audit := models.Base{CreatedBy: testUser.ID, UpdatedBy: testUser.ID}
+
+	category := models.Category{Base: audit, Name: "Laptops"}
+	db.Create(&category)
+	product := models.Product{Base: audit, Name: "ROG Strix", CategoryID: category.ID}
+	db.Create(&product)
+	sku := models.Sku{Base: audit, Name: "ROG Strix 16GB", SkuNumber: "ROG-16", Price: 100, ProductID: product.ID}
+	db.Create(&sku)
+
+	warranty := models.Attribute{Base: audit, Name: "Warranty Expiry", Code: "warranty_expiry", DataType: models.DataTypeDate}
+	db.Create(&warranty)
+	recalls := models.Attribute{Base: audit, Name: "Recall Dates", Code: "recall_dates", DataType: models.DataTypeDate, MultiValued: true}
+	db.Create(&recalls)
+
+	skuService := service.NewSkuService(db, service.NewCursorCodec("secret"))
+	ctx := context.Background()
+
+	upsert := func(locale string, inputs ...request.SkuAttributeValueInput) error {
+		_, err := skuService.UpsertAttributes(ctx, sku.ID, &request.UpsertSkuAttributesRequest{Attributes: inputs, Locale: locale})
+		return err
+	}
+	storedValues := func(attributeID uint) []string {
+		t.Helper()
+		var values []models.SkuAttributeValue
+		db.Where("sku_id = ? AND attribute_id = ?", sku.ID, attributeID).Order("position").Find(&values)
+		list := make([]string, len(values))
+		for i, value := range values {
+			list[i] = value.Value
+		}
+		return list
+	}
+
+	t.Run("Ambiguous date without locale is rejected", func(t *testing.T) {
+		err := upsert("", request.SkuAttributeValueInput{AttributeID: warranty.ID, Value: "03/04/2024"})
+		var validationErr *service.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected ValidationError, got %v", err)
+		}
+	})
+
+	t.Run("Unknown locale is rejected", func(t *testing.T) {
+		err := upsert("xx-XX", request.SkuAttributeValueInput{AttributeID: warranty.ID, Value: "03/04/2024"})
+		var validationErr *service.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected ValidationError, got %v", err)
+		}
+	})
+
+	t.Run("Locale decides the order and the ISO form is stored", func(t *testing.T) {
+		if err := upsert("en-US", request.SkuAttributeValueInput{AttributeID: warranty.ID, Value: "03/04/2024"}); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if got := storedValues(warranty.ID); len(got) != 1 || got[0] != "2024-03-04" {
+			t.Errorf("Expected [2024-03-04], got %v", got)
+		}
+
+		if err := upsert("en-GB", request.SkuAttributeValueInput{AttributeID: warranty.ID, Value: "03/04/2024"}); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if got := storedValues(warranty.ID); len(got) != 1 || got[0] != "2024-04-03" {
+			t.Errorf("Expected [2024-04-03], got %v", got)
+		}
+	})
+
+	t.Run("Every date of a list is converted", func(t *testing.T) {
+		err := upsert("de", request.SkuAttributeValueInput{AttributeID: recalls.ID, Values: []string{"1.2.2024", "2024-03-15"}})
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		got := storedValues(recalls.ID)
+		if len(got) != 2 || got[0] != "2024-02-01" || got[1] != "2024-03-15" {
+			t.Errorf("Expected [2024-02-01 2024-03-15], got %v", got)
+		}
+	})
+
+	t.Run("Direct writes are stored in ISO form", func(t *testing.T) {
+		var value models.SkuAttributeValue
+		db.Where("sku_id = ? AND attribute_id = ?", sku.ID, warranty.ID).First(&value)
+		value.Value = "12/31/2025"
+		if err := db.Save(&value).Error; err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if value.Value != "2025-12-31" {
+			t.Errorf("Expected value stored as 2025-12-31, got %q", value.Value)
+		}
+	})
+
+	t.Run("Switching to DATE stores the ISO form", func(t *testing.T) {
+		released := models.Attribute{Base: audit, Name: "Released", Code: "released", DataType: models.DataTypeText}
+		db.Create(&released)
+		if err := upsert("", request.SkuAttributeValueInput{AttributeID: released.ID, Value: "4 March 2024"}); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		attributeService := service.NewAttributeService(db, service.NewCursorCodec("secret"))
+		dataType := string(models.DataTypeDate)
+		if _, _, err := attributeService.Update(ctx, released.ID, &request.UpdateAttributeRequest{DataType: &dataType}, false); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if got := storedValues(released.ID); len(got) != 1 || got[0] != "2024-03-04" {
+			t.Errorf("Expected [2024-03-04], got %v", got)
+		}
+	})
+}
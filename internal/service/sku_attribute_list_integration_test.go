@@ -0,0 +1,172 @@
+//go:build integration
+// +build integration
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/internal/service"
+	"github.com/Wilson1510/klampis-pim-go/internal/testutil"
+)
+
+func TestSkuAttributeLists_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testUser := models.User{Username: "testuser", Password: "password123", Name: "Test User", Role: models.RoleUser}
+	if err := db.Create(&testUser).Error; err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	audit := models.Base{CreatedBy: testUser.ID, UpdatedBy: testUser.ID}
+
+	category := models.Category{Base: audit, Name: "Accessories"}
+	db.Create(&category)
+	product := models.Product{Base: audit, Name: "USB-C Hub", CategoryID: category.ID}
+	db.Create(&product)
+	sku := models.Sku{Base: audit, Name: "USB-C Hub 7-in-1", SkuNumber: "HUB-7", Price: 40, ProductID: product.ID}
+	db.Create(&sku)
+
+	platforms := models.Attribute{Base: audit, Name: "Compatible with", Code: "platforms", DataType: models.DataTypeText, MultiValued: true}
+	db.Create(&platforms)
+	ports := models.Attribute{Base: audit, Name: "Ports", Code: "ports", DataType: models.DataTypeNumber}
+	db.Create(&ports)
+
+	skuService := service.NewSkuService(db, service.NewCursorCodec("secret"))
+	attributeService := service.NewAttributeService(db, service.NewCursorCodec("secret"))
+	ctx := context.Background()
+
+	storedList := func(attributeID uint) []string {
+		t.Helper()
+		var values []models.SkuAttributeValue
+		db.Where("sku_id = ? AND attribute_id = ?", sku.ID, attributeID).Order("position").Find(&values)
+		list := make([]string, len(values))
+		for i, value := range values {
+			if value.Position != i {
+				t.Errorf("Expected position %d for %q, got %d", i, value.Value, value.Position)
+			}
+			list[i] = value.Value
+		}
+		return list
+	}
+	assertList := func(t *testing.T, attributeID uint, expected ...string) {
+		t.Helper()
+		got := storedList(attributeID)
+		if len(got) != len(expected) {
+			t.Fatalf("Expected values %v, got %v", expected, got)
+		}
+		for i := range expected {
+			if got[i] != expected[i] {
+				t.Errorf("Expected values %v, got %v", expected, got)
+				return
+			}
+		}
+	}
+	upsert := func(inputs ...request.SkuAttributeValueInput) error {
+		_, err := skuService.UpsertAttributes(ctx, sku.ID, &request.UpsertSkuAttributesRequest{Attributes: inputs})
+		return err
+	}
+
+	t.Run("Ordered list is stored by position", func(t *testing.T) {
+		err := upsert(request.SkuAttributeValueInput{AttributeID: platforms.ID, Values: []string{"iOS", "Android", "Windows"}})
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		assertList(t, platforms.ID, "iOS", "Android", "Windows")
+	})
+
+	t.Run("Upsert replaces the list", func(t *testing.T) {
+		err := upsert(request.SkuAttributeValueInput{AttributeID: platforms.ID, Values: []string{"Windows", "iOS"}})
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		assertList(t, platforms.ID, "Windows", "iOS")
+	})
+
+	t.Run("Repeated values are rejected", func(t *testing.T) {
+		err := upsert(request.SkuAttributeValueInput{AttributeID: platforms.ID, Values: []string{"iOS", "iOS"}})
+		var validationErr *service.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected ValidationError, got %v", err)
+		}
+	})
+
+	t.Run("Single-valued attribute takes one value", func(t *testing.T) {
+		err := upsert(request.SkuAttributeValueInput{AttributeID: ports.ID, Values: []string{"4", "7"}})
+		var validationErr *service.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected ValidationError, got %v", err)
+		}
+
+		if err := upsert(request.SkuAttributeValueInput{AttributeID: ports.ID, Value: "7"}); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		assertList(t, ports.ID, "7")
+	})
+
+	t.Run("Second value of a single-valued attribute is rejected", func(t *testing.T) {
+		value := models.SkuAttributeValue{SkuID: sku.ID, AttributeID: ports.ID, Value: "4", Position: 1}
+		if err := db.Create(&value).Error; err == nil {
+			t.Error("Expected a second position to be rejected")
+		}
+
+		duplicate := models.SkuAttributeValue{SkuID: sku.ID, AttributeID: ports.ID, Value: "4"}
+		if err := db.Create(&duplicate).Error; err == nil {
+			t.Error("Expected the unique index to reject a second value")
+		}
+	})
+
+	t.Run("Switching off multi-valued needs single values", func(t *testing.T) {
+		multiValued := false
+		_, _, err := attributeService.Update(ctx, platforms.ID, &request.UpdateAttributeRequest{MultiValued: &multiValued}, false)
+		var conflict *service.ConflictError
+		if !errors.As(err, &conflict) {
+			t.Errorf("Expected ConflictError, got %v", err)
+		}
+	})
+
+	t.Run("Merging options removes repeated values", func(t *testing.T) {
+		colors := models.Attribute{Base: audit, Name: "Colors", Code: "colors", DataType: models.DataTypeOption, MultiValued: true}
+		db.Create(&colors)
+		var options []*models.AttributeOption
+		for _, code := range []string{"red", "crimson", "blue"} {
+			option, err := attributeService.CreateOption(ctx, colors.ID, &request.CreateAttributeOptionRequest{Code: code, Label: code})
+			if err != nil {
+				t.Fatalf("Failed to create option %s: %v", code, err)
+			}
+			options = append(options, option)
+		}
+		if err := upsert(request.SkuAttributeValueInput{AttributeID: colors.ID, Values: []string{"red", "crimson", "blue"}}); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+
+		_, err := attributeService.MergeOptions(ctx, colors.ID, &request.MergeAttributeOptionsRequest{
+			SourceOptionIDs: []uint{options[1].ID},
+			TargetOptionID:  options[0].ID,
+		})
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		assertList(t, colors.ID, "red", "blue")
+	})
+
+	t.Run("Deleting the SKU keeps its values restorable", func(t *testing.T) {
+		if err := skuService.Delete(ctx, sku.ID); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if got := storedList(platforms.ID); len(got) != 0 {
+			t.Errorf("Expected no live values after delete, got %v", got)
+		}
+
+		var deleted int64
+		db.Unscoped().Model(&models.SkuAttributeValue{}).
+			Where("sku_id = ? AND deleted_at IS NOT NULL", sku.ID).Count(&deleted)
+		if deleted == 0 {
+			t.Error("Expected the attribute values to be soft-deleted with the SKU")
+		}
+	})
+}
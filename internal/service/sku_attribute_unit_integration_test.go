@@ -0,0 +1,159 @@
+//go:build integration
+// +build integration
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/internal/service"
+	"github.com/Wilson1510/klampis-pim-go/internal/testutil"
+)
+
+func TestSkuAttributeUnits_Integration(t *testing.T) {
+	db := testutil.SetupTestDB(t)
+	defer testutil.CleanupTestDB(t, db)
+
+	testUser := models.User{Username: "testuser", Password: "password123", Name: "Test User", Role: models.RoleUser}
+	if err := db.Create(&testUser).Error; err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	audit := models.Base{CreatedBy: testUser.ID, UpdatedBy: testUser.ID}
+
+	category := models.Category{Base: audit, Name: "Laptops"}
+	db.Create(&category)
+	product := models.Product{Base: audit, Name: "ROG Strix", CategoryID: category.ID}
+	db.Create(&product)
+	skus := make([]models.Sku, 3)
+	for i, number := range []string{"ROG-512", "ROG-1TB", "ROG-2TB"} {
+		skus[i] = models.Sku{Base: audit, Name: "ROG Strix " + number, SkuNumber: number, Price: 100, ProductID: product.ID}
+		db.Create(&skus[i])
+	}
+
+	skuService := service.NewSkuService(db, service.NewCursorCodec("secret"))
+	attributeService := service.NewAttributeService(db, service.NewCursorCodec("secret"))
+	ctx := context.Background()
+
+	storage, err := attributeService.Create(ctx, &request.CreateAttributeRequest{Name: "Storage", Code: "storage", DataType: "NUMBER", UOM: "gb"})
+	if err != nil {
+		t.Fatalf("Failed to create attribute: %v", err)
+	}
+
+	storedValue := func(skuID uint) models.SkuAttributeValue {
+		t.Helper()
+		var value models.SkuAttributeValue
+		if err := db.Where("sku_id = ? AND attribute_id = ?", skuID, storage.ID).First(&value).Error; err != nil {
+			t.Fatalf("Failed to load value: %v", err)
+		}
+		return value
+	}
+
+	t.Run("Unit is stored by its code", func(t *testing.T) {
+		if storage.UOM != "GB" {
+			t.Errorf("Expected UOM 'GB', got %q", storage.UOM)
+		}
+
+		_, err := attributeService.Create(ctx, &request.CreateAttributeRequest{Name: "Reach", Code: "reach", DataType: "NUMBER", UOM: "parsec"})
+		var validationErr *service.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected ValidationError for an unknown unit, got %v", err)
+		}
+	})
+
+	t.Run("Values in another unit are converted", func(t *testing.T) {
+		for i, value := range []string{"512", "1 TB", "2TB"} {
+			_, err := skuService.UpsertAttributes(ctx, skus[i].ID, &request.UpsertSkuAttributesRequest{
+				Attributes: []request.SkuAttributeValueInput{{AttributeID: storage.ID, Value: value}},
+			})
+			if err != nil {
+				t.Fatalf("Expected no error for %q but got: %v", value, err)
+			}
+		}
+
+		value := storedValue(skus[1].ID)
+		if value.Value != "1000" {
+			t.Errorf("Expected value stored as '1000', got %q", value.Value)
+		}
+		if value.BaseValue == nil || *value.BaseValue != 1e12 {
+			t.Errorf("Expected base value 1e12, got %v", value.BaseValue)
+		}
+	})
+
+	t.Run("Incompatible unit is rejected", func(t *testing.T) {
+		_, err := skuService.UpsertAttributes(ctx, skus[0].ID, &request.UpsertSkuAttributesRequest{
+			Attributes: []request.SkuAttributeValueInput{{AttributeID: storage.ID, Value: "3 GHz"}},
+		})
+		var validationErr *service.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("Expected ValidationError, got %v", err)
+		}
+	})
+
+	t.Run("Filter compares across units", func(t *testing.T) {
+		req := &request.GetSkusRequest{PaginationRequest: request.PaginationRequest{
+			Sort:    "-attr.storage",
+			Filters: []request.Filter{{Field: "attr.storage", Operator: request.FilterGe, Value: "1000000 MB"}},
+		}}
+		found, _, err := skuService.List(ctx, req)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if len(found) != 2 || found[0].ID != skus[2].ID || found[1].ID != skus[1].ID {
+			t.Errorf("Expected the 2 TB and 1 TB SKUs, got %v", found)
+		}
+	})
+
+	t.Run("Values are displayed in the requested unit", func(t *testing.T) {
+		values, err := skuService.Attributes(ctx, skus[0].ID)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if err := skuService.SetDisplayUnits(values, "TB"); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		display, err := values[0].GetDisplayValue(db)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		if display != "0.512 TB" {
+			t.Errorf("Expected '0.512 TB', got %q", display)
+		}
+	})
+
+	t.Run("Changing the unit converts stored values", func(t *testing.T) {
+		uom := "TB"
+		if _, _, err := attributeService.Update(ctx, storage.ID, &request.UpdateAttributeRequest{UOM: &uom}, false); err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		value := storedValue(skus[1].ID)
+		if value.Value != "1" || value.BaseValue == nil || *value.BaseValue != 1e12 {
+			t.Errorf("Expected 1000 GB as 1 with base value 1e12, got %q and %v", value.Value, value.BaseValue)
+		}
+		value = storedValue(skus[0].ID)
+		if value.Value != "0.512" || value.BaseValue == nil || *value.BaseValue != 512e9 {
+			t.Errorf("Expected 512 GB as 0.512 with base value 5.12e11, got %q and %v", value.Value, value.BaseValue)
+		}
+	})
+
+	t.Run("Changing to another dimension needs a relabel", func(t *testing.T) {
+		uom := "GHz"
+		_, _, err := attributeService.Update(ctx, storage.ID, &request.UpdateAttributeRequest{UOM: &uom}, false)
+		var validationErr *service.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("Expected ValidationError, got %v", err)
+		}
+
+		_, _, err = attributeService.Update(ctx, storage.ID, &request.UpdateAttributeRequest{UOM: &uom, RelabelValues: true}, false)
+		if err != nil {
+			t.Fatalf("Expected no error but got: %v", err)
+		}
+		value := storedValue(skus[1].ID)
+		if value.Value != "1" || value.BaseValue == nil || *value.BaseValue != 1e9 {
+			t.Errorf("Expected 1 relabelled with base value 1e9, got %q and %v", value.Value, value.BaseValue)
+		}
+	})
+}
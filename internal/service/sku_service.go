@@ -0,0 +1,500 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/response"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/Wilson1510/klampis-pim-go/pkg/utils"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// skuListSpec whitelists what SKU listings may filter and sort on
+var skuListSpec = listSpec{
+	filterFields: []string{"id", "name", "slug", "sku_number", "price", "product_id", "is_active", "sequence", "created_at", "updated_at"},
+	sortFields: sortColumns("id", "name", "slug", "sku_number", "price", "product_id", "is_active", "sequence", "created_at", "updated_at").with(map[string]string{
+		"product.name": "(SELECT products.name FROM products WHERE products.id = skus.product_id)",
+	}),
+	attributeSort:   true,
+	attributeFilter: true,
+}
+
+// SkuService contains the business logic for SKUs and their attribute values
+type SkuService struct {
+	db      *gorm.DB
+	cursors *CursorCodec
+}
+
+// NewSkuService creates a new SkuService
+func NewSkuService(db *gorm.DB, cursors *CursorCodec) *SkuService {
+	return &SkuService{db: db, cursors: cursors}
+}
+
+// List returns a page of SKUs matching the request filters
+func (s *SkuService) List(ctx context.Context, req *request.GetSkusRequest) ([]models.Sku, *PageInfo, error) {
+	query := s.db.WithContext(ctx).Model(&models.Sku{})
+
+	if req.Name != "" {
+		query = query.Where("name ILIKE ?", "%"+req.Name+"%")
+	}
+	if req.SkuNumber != "" {
+		query = query.Where("sku_number ILIKE ?", "%"+req.SkuNumber+"%")
+	}
+	if req.ProductID != nil {
+		query = query.Where("product_id = ?", *req.ProductID)
+	}
+	if req.IsActive != nil {
+		query = query.Where("is_active = ?", *req.IsActive)
+	}
+
+	var skus []models.Sku
+	page, err := paginate(query, &req.PaginationRequest, skuListSpec, s.cursors, &skus)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return skus, page, nil
+}
+
+// Get returns a single SKU with its product, images and attribute values preloaded
+func (s *SkuService) Get(ctx context.Context, id uint) (*models.Sku, error) {
+	var sku models.Sku
+	err := s.db.WithContext(ctx).
+		Preload("Product").
+		Preload("Images", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sequence, id")
+		}).
+		Preload("AttributeValues", func(db *gorm.DB) *gorm.DB {
+			return db.Order(attributeValueOrder)
+		}).
+		Preload("AttributeValues.Attribute").
+		Preload("AttributeValues.Attribute.Options").
+		First(&sku, id).Error
+	if err != nil {
+		return nil, translateNotFound(err)
+	}
+
+	return &sku, nil
+}
+
+// GetBySlug returns an active SKU by slug for the public catalog. A slug the SKU
+// used before a rename returns a MovedError.
+func (s *SkuService) GetBySlug(ctx context.Context, slug string) (*models.Sku, error) {
+	db := s.db.WithContext(ctx)
+
+	var sku models.Sku
+	err := db.
+		Preload("Product").
+		Preload("Images", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sequence, id")
+		}).
+		Preload("AttributeValues", func(db *gorm.DB) *gorm.DB {
+			return db.Order(attributeValueOrder)
+		}).
+		Preload("AttributeValues.Attribute").
+		Preload("AttributeValues.Attribute.Options").
+		Where("slug = ? AND is_active = ?", slug, true).
+		First(&sku).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, resolveMovedSlug(db, &models.Sku{}, "skus", slug)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &sku, nil
+}
+
+// Create creates a new SKU
+func (s *SkuService) Create(ctx context.Context, req *request.CreateSkuRequest) (*models.Sku, error) {
+	db := s.db.WithContext(ctx)
+
+	if err := ensureExists(db, &models.Product{}, req.ProductID, "product_id"); err != nil {
+		return nil, err
+	}
+	if err := s.ensureSkuNumberAvailable(db, req.SkuNumber, 0); err != nil {
+		return nil, err
+	}
+
+	sku := models.Sku{
+		Name:        req.Name,
+		Description: req.Description,
+		SkuNumber:   req.SkuNumber,
+		Price:       req.Price,
+		ProductID:   req.ProductID,
+	}
+	if req.IsActive != nil {
+		sku.IsActive = *req.IsActive
+	}
+	err := utils.RetryOnSlugConflict(IsSlugConflict, func() error {
+		return db.Create(&sku).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, sku.ID)
+}
+
+// Update applies the non-nil fields of the request to an existing SKU
+func (s *SkuService) Update(ctx context.Context, id uint, req *request.UpdateSkuRequest) (*models.Sku, error) {
+	db := s.db.WithContext(ctx)
+
+	var sku models.Sku
+	if err := db.First(&sku, id).Error; err != nil {
+		return nil, translateNotFound(err)
+	}
+
+	if req.Name != nil {
+		sku.Name = *req.Name
+	}
+	if req.Description != nil {
+		sku.Description = *req.Description
+	}
+	if req.SkuNumber != nil {
+		if err := s.ensureSkuNumberAvailable(db, *req.SkuNumber, sku.ID); err != nil {
+			return nil, err
+		}
+		sku.SkuNumber = *req.SkuNumber
+	}
+	if req.Price != nil {
+		sku.Price = *req.Price
+	}
+	if req.ProductID != nil {
+		if err := ensureExists(db, &models.Product{}, *req.ProductID, "product_id"); err != nil {
+			return nil, err
+		}
+		sku.ProductID = *req.ProductID
+	}
+	if req.IsActive != nil {
+		sku.IsActive = *req.IsActive
+	}
+	if req.SlugPinned != nil {
+		sku.SlugPinned = *req.SlugPinned
+	}
+
+	err := utils.RetryOnSlugConflict(IsSlugConflict, func() error {
+		return db.Omit(clause.Associations).Save(&sku).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, sku.ID)
+}
+
+// Delete soft-deletes a SKU together with its attribute values and images, so
+// that restoring the SKU brings its values back with it
+func (s *SkuService) Delete(ctx context.Context, id uint) error {
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var sku models.Sku
+		if err := tx.First(&sku, id).Error; err != nil {
+			return translateNotFound(err)
+		}
+
+		if err := tx.Where("sku_id = ?", id).Delete(&models.SkuAttributeValue{}).Error; err != nil {
+			return err
+		}
+
+		err := tx.Where("imageable_id = ? AND imageable_type = ?", id, "skus").
+			Delete(&models.Image{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Delete(&sku).Error
+	})
+}
+
+// Attributes returns the attribute values of a SKU joined with their attributes
+func (s *SkuService) Attributes(ctx context.Context, id uint) ([]models.SkuAttributeValue, error) {
+	db := s.db.WithContext(ctx)
+
+	if err := ensureExists(db, &models.Sku{}, id, ""); err != nil {
+		return nil, err
+	}
+
+	return loadSkuAttributeValues(db, id)
+}
+
+// SetDisplayUnits sets the unit every number is displayed in from a comma
+// separated list of units such as "TB,MHz": a number is displayed in the first
+// listed unit of its attribute's dimension. Attributes must be preloaded.
+func (s *SkuService) SetDisplayUnits(values []models.SkuAttributeValue, units string) error {
+	if strings.TrimSpace(units) == "" {
+		return nil
+	}
+
+	var displayUnits []*models.Unit
+	for _, name := range strings.Split(units, ",") {
+		unit, ok := models.LookupUnit(name)
+		if !ok {
+			return NewValidationError(map[string]string{"units": fmt.Sprintf("unknown unit %q", strings.TrimSpace(name))},
+				"unknown unit of measurement: %s", strings.TrimSpace(name))
+		}
+		displayUnits = append(displayUnits, unit)
+	}
+
+	for i := range values {
+		if values[i].Attribute == nil {
+			continue
+		}
+		from, ok := values[i].Attribute.Unit()
+		if !ok {
+			continue
+		}
+		for _, unit := range displayUnits {
+			if unit.Dimension == from.Dimension {
+				values[i].DisplayUnit = unit
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// UpsertAttributes adds or updates attribute values of a SKU in a single transaction.
+// Every value is validated first; if any is invalid nothing is written and a
+// ValidationError listing each rejected value is returned.
+func (s *SkuService) UpsertAttributes(ctx context.Context, id uint, req *request.UpsertSkuAttributesRequest) ([]models.SkuAttributeValue, error) {
+	var values []models.SkuAttributeValue
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := ensureExists(tx, &models.Sku{}, id, ""); err != nil {
+			return err
+		}
+
+		if req.Locale != "" && !utils.HasDateLocale(req.Locale) {
+			return NewValidationError(map[string]string{"locale": "is not a supported date locale"},
+				"unsupported date locale: %s", req.Locale)
+		}
+
+		attributes, err := loadAttributesByID(tx, req.Attributes)
+		if err != nil {
+			return err
+		}
+
+		if errs := validateAttributeInputs(req.Attributes, attributes, req.Locale); len(errs) > 0 {
+			return NewValidationError(errs, "%d attribute value(s) are invalid", len(errs))
+		}
+
+		for _, input := range req.Attributes {
+			if err := upsertAttributeValues(tx, id, input); err != nil {
+				return err
+			}
+		}
+
+		values, err = loadSkuAttributeValues(tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// RemoveAttribute deletes a single attribute value from a SKU
+func (s *SkuService) RemoveAttribute(ctx context.Context, id uint, attributeID uint) error {
+	db := s.db.WithContext(ctx)
+
+	if err := ensureExists(db, &models.Sku{}, id, ""); err != nil {
+		return err
+	}
+
+	result := db.Unscoped().
+		Where("sku_id = ? AND attribute_id = ?", id, attributeID).
+		Delete(&models.SkuAttributeValue{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// ensureSkuNumberAvailable checks that no other SKU already uses skuNumber
+func (s *SkuService) ensureSkuNumberAvailable(db *gorm.DB, skuNumber string, excludeID uint) error {
+	var count int64
+	query := db.Model(&models.Sku{}).Where("sku_number = ?", skuNumber)
+	if excludeID != 0 {
+		query = query.Where("id != ?", excludeID)
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return NewConflictError(map[string]string{"sku_number": "is already in use"},
+			"sku number %s is already in use", skuNumber)
+	}
+
+	return nil
+}
+
+// loadAttributesByID fetches the attributes referenced by the inputs, keyed by ID
+func loadAttributesByID(db *gorm.DB, inputs []request.SkuAttributeValueInput) (map[uint]models.Attribute, error) {
+	ids := make([]uint, 0, len(inputs))
+	for _, input := range inputs {
+		ids = append(ids, input.AttributeID)
+	}
+
+	var attributes []models.Attribute
+	if err := db.Preload("Options").Where("id IN ?", ids).Find(&attributes).Error; err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint]models.Attribute, len(attributes))
+	for _, attribute := range attributes {
+		byID[attribute.ID] = attribute
+	}
+
+	return byID, nil
+}
+
+// validateAttributeInputs checks every input and returns one error per rejected value.
+// Dates are rewritten to their ISO form, reading numeric dates for the locale.
+func validateAttributeInputs(inputs []request.SkuAttributeValueInput, attributes map[uint]models.Attribute, locale string) []response.AttributeValueError {
+	var errs []response.AttributeValueError
+	seen := make(map[uint]bool, len(inputs))
+
+	for i, input := range inputs {
+		reject := func(message string) {
+			errs = append(errs, response.AttributeValueError{
+				Index:       i,
+				AttributeID: input.AttributeID,
+				Value:       input.Value,
+				Values:      input.Values,
+				Message:     message,
+			})
+		}
+
+		if seen[input.AttributeID] {
+			reject("attribute appears more than once in the request")
+			continue
+		}
+		seen[input.AttributeID] = true
+
+		attribute, ok := attributes[input.AttributeID]
+		if !ok {
+			reject("attribute does not exist")
+			continue
+		}
+
+		if attribute.DataType == models.DataTypeDate {
+			if err := canonicalizeDates(&inputs[i], locale); err != nil {
+				reject(fmt.Sprintf("invalid value for attribute '%s' (type: %s): %v",
+					attribute.Name, attribute.DataType, err))
+				continue
+			}
+		}
+
+		if err := attribute.ValidateValues(inputs[i].List()); err != nil {
+			reject(fmt.Sprintf("invalid value for attribute '%s' (type: %s): %v",
+				attribute.Name, attribute.DataType, err))
+
+			var violation *models.RuleViolationError
+			if errors.As(err, &violation) {
+				errs[len(errs)-1].Rule = violation.Rule
+				errs[len(errs)-1].Limit = violation.Limit
+			}
+		}
+	}
+
+	return errs
+}
+
+// canonicalizeDates rewrites the dates of a DATE input to their ISO form, reading
+// numeric dates the way the locale writes them
+func canonicalizeDates(input *request.SkuAttributeValueInput, locale string) error {
+	list := input.List()
+	dates := make([]string, len(list))
+	for i, item := range list {
+		date, err := utils.ParseDate(item, locale)
+		if err != nil {
+			return err
+		}
+		dates[i] = date.Format(utils.DateLayout)
+	}
+
+	if len(input.Values) > 0 {
+		input.Values = dates
+	} else {
+		input.Value = dates[0]
+	}
+	return nil
+}
+
+// upsertAttributeValues replaces the values of an attribute on a SKU with the
+// list of the input. Stored values are updated in place by position, missing
+// ones are created and the values past the end of the list are deleted.
+func upsertAttributeValues(tx *gorm.DB, skuID uint, input request.SkuAttributeValueInput) error {
+	var existing []models.SkuAttributeValue
+	err := tx.Where("sku_id = ? AND attribute_id = ?", skuID, input.AttributeID).
+		Order("position").Find(&existing).Error
+	if err != nil {
+		return err
+	}
+
+	// Every value of a list shares the sequence of the attribute
+	sequence := 0
+	if len(existing) > 0 {
+		sequence = existing[0].Sequence
+	}
+	if input.Sequence != nil {
+		sequence = *input.Sequence
+	}
+
+	list := input.List()
+	if len(existing) > len(list) {
+		if err := tx.Unscoped().Delete(existing[len(list):]).Error; err != nil {
+			return err
+		}
+	}
+
+	for position, item := range list {
+		value := models.SkuAttributeValue{SkuID: skuID, AttributeID: input.AttributeID}
+		if position < len(existing) {
+			value = existing[position]
+		}
+		value.Value = item
+		value.Position = position
+		value.Sequence = sequence
+
+		if value.ID == 0 {
+			err = tx.Create(&value).Error
+		} else {
+			err = tx.Omit(clause.Associations).Save(&value).Error
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// attributeValueOrder orders the attribute values of a SKU for display, keeping
+// the values of a multi-valued attribute together and in list order
+const attributeValueOrder = "sequence, attribute_id, position"
+
+// loadSkuAttributeValues loads the attribute values of a SKU ordered for display
+func loadSkuAttributeValues(db *gorm.DB, skuID uint) ([]models.SkuAttributeValue, error) {
+	var values []models.SkuAttributeValue
+	err := db.Preload("Attribute").
+		Preload("Attribute.Options").
+		Where("sku_id = ?", skuID).
+		Order(attributeValueOrder).
+		Find(&values).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestValidateAttributeInputs(t *testing.T) {
+	// Setup
+	attributes := map[uint]models.Attribute{
+		1: {Base: models.Base{Model: gorm.Model{ID: 1}}, Name: "RAM", DataType: models.DataTypeNumber},
+		2: {Base: models.Base{Model: gorm.Model{ID: 2}}, Name: "Color", DataType: models.DataTypeText},
+		3: {Base: models.Base{Model: gorm.Model{ID: 3}}, Name: "Wireless", DataType: models.DataTypeBoolean},
+		4: {Base: models.Base{Model: gorm.Model{ID: 4}}, Name: "Release Date", DataType: models.DataTypeDate},
+	}
+
+	testCases := []struct {
+		name            string
+		inputs          []request.SkuAttributeValueInput
+		locale          string
+		expectedIndexes []int
+	}{
+		{
+			name: "All values valid",
+			inputs: []request.SkuAttributeValueInput{
+				{AttributeID: 1, Value: "16"},
+				{AttributeID: 2, Value: "Black"},
+				{AttributeID: 3, Value: "true"},
+			},
+			expectedIndexes: nil,
+		},
+		{
+			name: "Every invalid value is reported",
+			inputs: []request.SkuAttributeValueInput{
+				{AttributeID: 1, Value: "sixteen"},
+				{AttributeID: 2, Value: "Black"},
+				{AttributeID: 3, Value: "maybe"},
+			},
+			expectedIndexes: []int{0, 2},
+		},
+		{
+			name: "Unknown attribute",
+			inputs: []request.SkuAttributeValueInput{
+				{AttributeID: 99, Value: "x"},
+			},
+			expectedIndexes: []int{0},
+		},
+		{
+			name: "Duplicate attribute in request",
+			inputs: []request.SkuAttributeValueInput{
+				{AttributeID: 2, Value: "Black"},
+				{AttributeID: 2, Value: "White"},
+			},
+			expectedIndexes: []int{1},
+		},
+		{
+			name: "Ambiguous date without locale",
+			inputs: []request.SkuAttributeValueInput{
+				{AttributeID: 4, Value: "03/04/2024"},
+			},
+			expectedIndexes: []int{0},
+		},
+		{
+			name: "Ambiguous date with locale",
+			inputs: []request.SkuAttributeValueInput{
+				{AttributeID: 4, Value: "03/04/2024"},
+			},
+			locale:          "en-US",
+			expectedIndexes: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := validateAttributeInputs(tc.inputs, attributes, tc.locale)
+
+			var indexes []int
+			for _, e := range errs {
+				indexes = append(indexes, e.Index)
+				assert.Equal(t, tc.inputs[e.Index].AttributeID, e.AttributeID)
+				assert.Equal(t, tc.inputs[e.Index].Value, e.Value)
+				assert.NotEmpty(t, e.Message)
+			}
+			assert.Equal(t, tc.expectedIndexes, indexes)
+		})
+	}
+}
+
+func TestValidateAttributeInputsCanonicalizesDates(t *testing.T) {
+	// Setup
+	attributes := map[uint]models.Attribute{
+		1: {Base: models.Base{Model: gorm.Model{ID: 1}}, Name: "Release Date", DataType: models.DataTypeDate},
+		2: {Base: models.Base{Model: gorm.Model{ID: 2}}, Name: "Recall Dates", DataType: models.DataTypeDate, MultiValued: true},
+	}
+	inputs := []request.SkuAttributeValueInput{
+		{AttributeID: 1, Value: "03/04/2024"},
+		{AttributeID: 2, Values: []string{"1/2/2024", "Mar 15, 2024"}},
+	}
+
+	// Execute
+	errs := validateAttributeInputs(inputs, attributes, "en-GB")
+
+	// Assert
+	assert.Empty(t, errs)
+	assert.Equal(t, "2024-04-03", inputs[0].Value)
+	assert.Equal(t, []string{"2024-02-01", "2024-03-15"}, inputs[1].Values)
+}
+
+func TestSetDisplayUnits(t *testing.T) {
+	// Setup
+	storage := &models.Attribute{Name: "Storage", DataType: models.DataTypeNumber, UOM: "GB"}
+	speed := &models.Attribute{Name: "CPU Speed", DataType: models.DataTypeNumber, UOM: "GHz"}
+	model := &models.Attribute{Name: "Model", DataType: models.DataTypeText}
+	values := []models.SkuAttributeValue{
+		{Value: "512", Attribute: storage},
+		{Value: "2.4", Attribute: speed},
+		{Value: "G15", Attribute: model},
+	}
+	skuService := &SkuService{}
+
+	// Execute
+	err := skuService.SetDisplayUnits(values, "TB, TiB")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "TB", values[0].DisplayUnit.Code)
+	assert.Nil(t, values[1].DisplayUnit)
+	assert.Nil(t, values[2].DisplayUnit)
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, skuService.SetDisplayUnits(values, "TB,parsec"), &validationErr)
+}
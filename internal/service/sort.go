@@ -0,0 +1,159 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// attributeSortPrefix selects sorting by a SKU attribute value, as in attr.ram
+const attributeSortPrefix = "attr."
+
+// maxSortKeys limits how many terms a single ?sort= may contain
+const maxSortKeys = 5
+
+// sortExpressions maps sort keys to the SQL they order by
+type sortExpressions map[string]clause.Expr
+
+// sortColumns returns sort expressions for plain columns of the listed table
+func sortColumns(names ...string) sortExpressions {
+	expressions := make(sortExpressions, len(names))
+	for _, name := range names {
+		expressions[name] = clause.Expr{
+			SQL:  "?",
+			Vars: []interface{}{clause.Column{Table: clause.CurrentTable, Name: name}},
+		}
+	}
+	return expressions
+}
+
+// with adds sort keys backed by custom SQL, typically a correlated subquery on a related table
+func (e sortExpressions) with(related map[string]string) sortExpressions {
+	for key, sql := range related {
+		e[key] = clause.Expr{SQL: sql}
+	}
+	return e
+}
+
+// keys returns the accepted sort keys in a stable order
+func (e sortExpressions) keys() []string {
+	keys := make([]string, 0, len(e))
+	for key := range e {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// applySort orders query by the requested sort keys, which must be whitelisted
+// in spec. The primary key is always appended as a tie-breaker so pages are stable.
+func applySort(query *gorm.DB, keys []request.SortKey, spec listSpec) (*gorm.DB, error) {
+	if len(keys) > maxSortKeys {
+		return nil, NewValidationError(map[string]string{"sort": fmt.Sprintf("must have at most %d keys", maxSortKeys)},
+			"too many sort keys")
+	}
+
+	var terms []string
+	var vars []interface{}
+	details := make(map[string]interface{})
+	hasID := false
+
+	for _, key := range keys {
+		expr, problem, err := sortExpression(query, key.Field, spec)
+		if err != nil {
+			return nil, err
+		}
+		if problem != "" {
+			details[key.Field] = problem
+			continue
+		}
+		hasID = hasID || key.Field == "id"
+
+		term := "? ASC"
+		if key.Desc {
+			term = "? DESC"
+		}
+		// Related rows and attribute values may be missing; keep those rows last either way
+		if !isColumnExpr(expr) {
+			term += " NULLS LAST"
+		}
+		terms = append(terms, term)
+		vars = append(vars, expr)
+	}
+
+	if len(details) > 0 {
+		allowed := spec.sortFields.keys()
+		if spec.attributeSort {
+			allowed = append(allowed, attributeSortPrefix+"<code>")
+		}
+		details["allowed_sort_fields"] = allowed
+		return nil, NewValidationError(details, "invalid sort parameters")
+	}
+
+	if !hasID {
+		terms = append(terms, "?")
+		vars = append(vars, clause.Column{Table: clause.CurrentTable, Name: clause.PrimaryKey})
+	}
+
+	return query.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:                strings.Join(terms, ", "),
+		Vars:               vars,
+		WithoutParentheses: true,
+	}}), nil
+}
+
+// sortExpression resolves a single sort key against the spec. Keys that are not
+// allowed are described by problem; err is only set when the lookup itself fails.
+func sortExpression(query *gorm.DB, field string, spec listSpec) (expr clause.Expr, problem string, err error) {
+	if expr, ok := spec.sortFields[field]; ok {
+		return expr, "", nil
+	}
+
+	if code, ok := strings.CutPrefix(field, attributeSortPrefix); ok && spec.attributeSort {
+		return attributeSortExpression(query, code)
+	}
+
+	return clause.Expr{}, "unknown sort field", nil
+}
+
+// attributeSortExpression orders SKUs by their value for the attribute with code.
+// NUMBER attributes compare numerically by base value; other types compare as text.
+func attributeSortExpression(query *gorm.DB, code string) (clause.Expr, string, error) {
+	var attribute models.Attribute
+	err := query.Session(&gorm.Session{NewDB: true}).
+		Select("id", "data_type").
+		Where("code = ?", code).
+		First(&attribute).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return clause.Expr{}, fmt.Sprintf("unknown attribute code %q", code), nil
+	}
+	if err != nil {
+		return clause.Expr{}, "", err
+	}
+
+	value := "v.value"
+	if attribute.DataType == models.DataTypeNumber {
+		value = "v.base_value"
+	}
+
+	return clause.Expr{
+		SQL: "(SELECT MIN(" + value + ") FROM sku_attribute_values v" +
+			" WHERE v.sku_id = skus.id AND v.attribute_id = ? AND v.deleted_at IS NULL)",
+		Vars: []interface{}{attribute.ID},
+	}, "", nil
+}
+
+// isColumnExpr reports whether expr is a plain column built by sortColumns
+func isColumnExpr(expr clause.Expr) bool {
+	if expr.SQL != "?" || len(expr.Vars) != 1 {
+		return false
+	}
+	_, ok := expr.Vars[0].(clause.Column)
+	return ok
+}
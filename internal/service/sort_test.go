@@ -0,0 +1,80 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplySort(t *testing.T) {
+	db := newDryRunDB(t)
+
+	testCases := []struct {
+		name          string
+		keys          []request.SortKey
+		expectedOrder string
+	}{
+		{
+			name:          "Multiple columns with id tie-breaker",
+			keys:          []request.SortKey{{Field: "price", Desc: true}, {Field: "name"}},
+			expectedOrder: `ORDER BY "skus"."price" DESC, "skus"."name" ASC, "skus"."id"`,
+		},
+		{
+			name:          "Explicit id is not repeated",
+			keys:          []request.SortKey{{Field: "id", Desc: true}},
+			expectedOrder: `ORDER BY "skus"."id" DESC LIMIT`,
+		},
+		{
+			name:          "Related column",
+			keys:          []request.SortKey{{Field: "product.name"}},
+			expectedOrder: `ORDER BY (SELECT products.name FROM products WHERE products.id = skus.product_id) ASC NULLS LAST, "skus"."id"`,
+		},
+		{
+			name:          "Attribute value",
+			keys:          []request.SortKey{{Field: "attr.ram", Desc: true}},
+			expectedOrder: `FROM sku_attribute_values v WHERE v.sku_id = skus.id AND v.attribute_id = $1 AND v.deleted_at IS NULL) DESC NULLS LAST, "skus"."id"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := applySort(db.Model(&models.Sku{}), tc.keys, skuListSpec)
+			require.NoError(t, err)
+
+			var skus []models.Sku
+			stmt := query.Limit(10).Find(&skus).Statement
+
+			assert.Contains(t, stmt.SQL.String(), tc.expectedOrder)
+		})
+	}
+}
+
+func TestApplySortErrors(t *testing.T) {
+	db := newDryRunDB(t)
+
+	testCases := []struct {
+		name        string
+		spec        listSpec
+		keys        []request.SortKey
+		expectedKey string
+	}{
+		{name: "Unknown column", spec: skuListSpec, keys: []request.SortKey{{Field: "cost"}}, expectedKey: "cost"},
+		{name: "SQL injection attempt", spec: skuListSpec, keys: []request.SortKey{{Field: "name; DROP TABLE skus"}}, expectedKey: "name; DROP TABLE skus"},
+		{name: "Attribute sort outside SKUs", spec: productListSpec, keys: []request.SortKey{{Field: "attr.ram"}}, expectedKey: "attr.ram"},
+		{name: "Relation not whitelisted", spec: productListSpec, keys: []request.SortKey{{Field: "category.slug"}}, expectedKey: "category.slug"},
+		{name: "Too many keys", spec: skuListSpec, keys: make([]request.SortKey, maxSortKeys+1), expectedKey: "sort"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := applySort(db.Model(&models.Sku{}), tc.keys, tc.spec)
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Contains(t, validationErr.Details, tc.expectedKey)
+		})
+	}
+}
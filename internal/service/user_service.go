@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Wilson1510/klampis-pim-go/internal/auth"
+	"github.com/Wilson1510/klampis-pim-go/internal/dto/request"
+	"github.com/Wilson1510/klampis-pim-go/internal/models"
+	"gorm.io/gorm"
+)
+
+// userListSpec whitelists what user listings may filter and sort on
+var userListSpec = listSpec{
+	filterFields: []string{"id", "username", "name", "role", "created_at", "updated_at"},
+	sortFields:   sortColumns("id", "username", "name", "role", "created_at", "updated_at"),
+}
+
+// UserService contains the business logic for managing user accounts
+type UserService struct {
+	db      *gorm.DB
+	cursors *CursorCodec
+}
+
+// NewUserService creates a new UserService
+func NewUserService(db *gorm.DB, cursors *CursorCodec) *UserService {
+	return &UserService{db: db, cursors: cursors}
+}
+
+// List returns a page of users matching the request filters
+func (s *UserService) List(ctx context.Context, req *request.GetUsersRequest) ([]models.User, *PageInfo, error) {
+	query := s.db.WithContext(ctx).Model(&models.User{})
+
+	if req.Username != "" {
+		query = query.Where("username ILIKE ?", "%"+req.Username+"%")
+	}
+	if req.Role != "" {
+		query = query.Where("role = ?", req.Role)
+	}
+
+	var users []models.User
+	page, err := paginate(query, &req.PaginationRequest, userListSpec, s.cursors, &users)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return users, page, nil
+}
+
+// Get returns a single user
+func (s *UserService) Get(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+	if err := s.db.WithContext(ctx).First(&user, id).Error; err != nil {
+		return nil, translateNotFound(err)
+	}
+
+	return &user, nil
+}
+
+// Create creates a new user. The password is hashed by the User BeforeCreate hook.
+func (s *UserService) Create(ctx context.Context, req *request.CreateUserRequest) (*models.User, error) {
+	db := s.db.WithContext(ctx)
+
+	if err := s.ensureUsernameAvailable(db, req.Username); err != nil {
+		return nil, err
+	}
+
+	user := models.User{
+		Username: req.Username,
+		Password: req.Password,
+		Name:     req.Name,
+		Role:     models.UserRole(req.Role),
+	}
+	if err := db.Create(&user).Error; err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// Update applies the non-nil fields of the request to an existing user.
+// Changing the password signs the user out of every session.
+func (s *UserService) Update(ctx context.Context, id uint, req *request.UpdateUserRequest) (*models.User, error) {
+	var user models.User
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.First(&user, id).Error; err != nil {
+			return translateNotFound(err)
+		}
+		if err := ensureNotSystemUser(&user); err != nil {
+			return err
+		}
+
+		if req.Name != nil {
+			user.Name = *req.Name
+		}
+		if req.Role != nil {
+			user.Role = models.UserRole(*req.Role)
+		}
+		if req.Password != nil {
+			// Hashed by the User BeforeUpdate hook
+			user.Password = *req.Password
+		}
+		if err := tx.Save(&user).Error; err != nil {
+			return err
+		}
+
+		if req.Password != nil {
+			return revokeUserRefreshTokens(tx, user.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// Delete removes a user account and revokes its refresh tokens.
+// The system account and the acting user's own account cannot be deleted.
+func (s *UserService) Delete(ctx context.Context, id uint) error {
+	if actor, ok := auth.UserFromContext(ctx); ok && actor.ID == id {
+		return NewConflictError(nil, "users cannot delete their own account")
+	}
+
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.First(&user, id).Error; err != nil {
+			return translateNotFound(err)
+		}
+		if err := ensureNotSystemUser(&user); err != nil {
+			return err
+		}
+
+		if err := revokeUserRefreshTokens(tx, user.ID); err != nil {
+			return err
+		}
+		return tx.Delete(&user).Error
+	})
+}
+
+// ensureUsernameAvailable checks that no user already uses username
+func (s *UserService) ensureUsernameAvailable(db *gorm.DB, username string) error {
+	var count int64
+	if err := db.Model(&models.User{}).Where("username = ?", username).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return NewConflictError(map[string]string{"username": "is already in use"},
+			"username %s is already in use", username)
+	}
+
+	return nil
+}
+
+// ensureNotSystemUser rejects changes to the seeded system account
+func ensureNotSystemUser(user *models.User) error {
+	if user.Role == models.RoleSystem {
+		return NewConflictError(nil, "the system account cannot be modified")
+	}
+	return nil
+}
+
+// revokeUserRefreshTokens revokes every still active refresh token of userID
+func revokeUserRefreshTokens(tx *gorm.DB, userID uint) error {
+	return tx.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
@@ -181,6 +181,11 @@ func runMigrations(db *gorm.DB) error {
 		&models.Attribute{},
 		&models.SkuAttributeValue{},
 		&models.Image{},
+		&models.RefreshToken{},
+		&models.RevokedToken{},
+		&models.SlugHistory{},
+		&models.SlugSequence{},
+		&models.AttributeOption{},
 	)
 }
 
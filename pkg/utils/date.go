@@ -0,0 +1,154 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// DateLayout is the canonical ISO form dates are stored in
+const DateLayout = "2006-01-02"
+
+// unambiguousDateLayouts are accepted with or without a locale, as they can only be read one way
+var unambiguousDateLayouts = []string{
+	DateLayout,            // 2023-12-31
+	"2006-01-02 15:04:05", // 2023-12-31 23:59:59
+	time.RFC3339,          // 2023-12-31T23:59:59Z
+	"2006/01/02",          // 2023/12/31
+	"2 Jan 2006",          // 31 Dec 2023
+	"2 January 2006",      // 31 December 2023
+	"Jan 2, 2006",         // Dec 31, 2023
+	"January 2, 2006",     // December 31, 2023
+}
+
+// DateLocales maps a locale such as en-US to the date layouts accepted for it on
+// top of the unambiguous layouts, in the order they are tried. A locale is looked up
+// ignoring case, and a locale without an entry falls back to its language
+// (de-AT uses de).
+type DateLocales map[string][]string
+
+var dateLocales atomic.Pointer[DateLocales]
+
+func init() {
+	SetDateLocales(DefaultDateLocales())
+}
+
+// DefaultDateLocales returns the built-in locales. English has no language-wide
+// entry, as US and British dates put day and month the other way round.
+func DefaultDateLocales() DateLocales {
+	return DateLocales{
+		"en-us": {"1/2/2006", "1-2-2006"},
+		"en-gb": {"2/1/2006", "2-1-2006", "2.1.2006"},
+		"en-au": {"2/1/2006", "2-1-2006", "2.1.2006"},
+		"id":    {"2/1/2006", "2-1-2006", "2.1.2006"},
+		"de":    {"2.1.2006", "2.1.06"},
+		"fr":    {"2/1/2006", "2.1.2006"},
+		"nl":    {"2-1-2006", "2/1/2006"},
+	}
+}
+
+// SetDateLocales replaces the locales used by ParseDate
+func SetDateLocales(locales DateLocales) {
+	normalized := make(DateLocales, len(locales))
+	for locale, layouts := range locales {
+		normalized[normalizeLocale(locale)] = layouts
+	}
+	dateLocales.Store(&normalized)
+}
+
+// HasDateLocale reports whether dates can be parsed for locale
+func HasDateLocale(locale string) bool {
+	_, ok := lookupDateLocale(locale)
+	return ok
+}
+
+// ParseDate parses a date input. ISO dates and dates with a month name are
+// always accepted. With a locale, the layouts of that locale are tried in order.
+// Without one, a date such as 12/31/2023 is only accepted when it reads the same
+// day-first and month-first or can only be read one way, so 03/04/2024 is
+// rejected as ambiguous.
+func ParseDate(value, locale string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range unambiguousDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	if locale != "" {
+		layouts, ok := lookupDateLocale(locale)
+		if !ok {
+			return time.Time{}, fmt.Errorf("unsupported date locale: %s", locale)
+		}
+		for _, layout := range layouts {
+			if t, err := time.Parse(layout, value); err == nil {
+				return t, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("invalid date format for locale %s: %q", locale, value)
+	}
+
+	dayFirst, dayErr := time.Parse("2/1/2006", value)
+	monthFirst, monthErr := time.Parse("1/2/2006", value)
+	switch {
+	case dayErr == nil && monthErr == nil && !dayFirst.Equal(monthFirst):
+		return time.Time{}, fmt.Errorf("ambiguous date %q could be %s or %s, give a locale or use YYYY-MM-DD",
+			value, dayFirst.Format(DateLayout), monthFirst.Format(DateLayout))
+	case dayErr == nil:
+		return dayFirst, nil
+	case monthErr == nil:
+		return monthFirst, nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date format, use YYYY-MM-DD: %q", value)
+}
+
+// ParseDateLocales reads locales in the form
+// "en-US=1/2/2006|Jan 2, 2006;en-GB=2/1/2006", with the layouts of each locale
+// separated by "|" and locales by ";". Locales are returned lowercased like the
+// keys of DefaultDateLocales, so the two can be merged.
+func ParseDateLocales(s string) (DateLocales, error) {
+	locales := DateLocales{}
+	for _, entry := range strings.Split(s, ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		locale, list, ok := strings.Cut(entry, "=")
+		locale = strings.TrimSpace(locale)
+		if !ok || locale == "" {
+			return nil, fmt.Errorf("invalid date locale entry %q, expected locale=layout|layout", entry)
+		}
+
+		var layouts []string
+		for _, layout := range strings.Split(list, "|") {
+			if layout = strings.TrimSpace(layout); layout != "" {
+				layouts = append(layouts, layout)
+			}
+		}
+		if len(layouts) == 0 {
+			return nil, fmt.Errorf("date locale %s has no layouts", locale)
+		}
+		locales[normalizeLocale(locale)] = layouts
+	}
+	return locales, nil
+}
+
+// lookupDateLocale returns the layouts of locale, falling back to its language
+func lookupDateLocale(locale string) ([]string, bool) {
+	locales := *dateLocales.Load()
+	locale = normalizeLocale(locale)
+	if layouts, ok := locales[locale]; ok {
+		return layouts, true
+	}
+	if language, _, found := strings.Cut(locale, "-"); found {
+		layouts, ok := locales[language]
+		return layouts, ok
+	}
+	return nil, false
+}
+
+// normalizeLocale lowercases a locale and writes en_US as en-us
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
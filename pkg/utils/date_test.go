@@ -0,0 +1,142 @@
+package utils
+
+import (
+	"testing"
+)
+
+// TestParseDate tests the ParseDate function
+func TestParseDate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		value       string
+		locale      string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "ISO date",
+			value:    "2024-03-04",
+			expected: "2024-03-04",
+		},
+		{
+			name:     "ISO datetime",
+			value:    "2024-03-04 10:30:00",
+			expected: "2024-03-04",
+		},
+		{
+			name:     "Month name",
+			value:    "Mar 4, 2024",
+			expected: "2024-03-04",
+		},
+		{
+			name:     "ISO date ignores locale",
+			value:    "2024-03-04",
+			locale:   "en-US",
+			expected: "2024-03-04",
+		},
+		{
+			name:        "Ambiguous date without locale",
+			value:       "03/04/2024",
+			expectError: true,
+		},
+		{
+			name:     "Day-first date without locale",
+			value:    "31/12/2023",
+			expected: "2023-12-31",
+		},
+		{
+			name:     "Month-first date without locale",
+			value:    "12/31/2023",
+			expected: "2023-12-31",
+		},
+		{
+			name:     "Same day and month without locale",
+			value:    "05/05/2024",
+			expected: "2024-05-05",
+		},
+		{
+			name:     "US locale reads month first",
+			value:    "03/04/2024",
+			locale:   "en-US",
+			expected: "2024-03-04",
+		},
+		{
+			name:     "British locale reads day first",
+			value:    "03/04/2024",
+			locale:   "en_GB",
+			expected: "2024-04-03",
+		},
+		{
+			name:     "Region falls back to language",
+			value:    "03.04.2024",
+			locale:   "de-AT",
+			expected: "2024-04-03",
+		},
+		{
+			name:        "Day-first date in US locale",
+			value:       "31/12/2023",
+			locale:      "en-US",
+			expectError: true,
+		},
+		{
+			name:        "Unknown locale",
+			value:       "03/04/2024",
+			locale:      "xx",
+			expectError: true,
+		},
+		{
+			name:        "English without region",
+			value:       "03/04/2024",
+			locale:      "en",
+			expectError: true,
+		},
+		{
+			name:        "Not a date",
+			value:       "not-a-date",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ParseDate(tc.value, tc.locale)
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Expected error for %q, got %s", tc.value, result.Format(DateLayout))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error for %q: %v", tc.value, err)
+			}
+			if got := result.Format(DateLayout); got != tc.expected {
+				t.Errorf("ParseDate(%q, %q) = %s, expected %s", tc.value, tc.locale, got, tc.expected)
+			}
+		})
+	}
+}
+
+// TestParseDateLocales tests the ParseDateLocales function
+func TestParseDateLocales(t *testing.T) {
+	locales, err := ParseDateLocales("en-US = 1/2/2006 | Jan 2, 2006; sv=2006-01-02;")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(locales) != 2 {
+		t.Fatalf("Expected 2 locales, got %d", len(locales))
+	}
+	if layouts := locales["en-us"]; len(layouts) != 2 || layouts[1] != "Jan 2, 2006" {
+		t.Errorf("Expected two trimmed en-us layouts, got %q", layouts)
+	}
+
+	for _, invalid := range []string{"en-US", "=1/2/2006", "en-US=|"} {
+		if _, err := ParseDateLocales(invalid); err == nil {
+			t.Errorf("Expected error for %q", invalid)
+		}
+	}
+
+	if locales, err := ParseDateLocales(""); err != nil || len(locales) != 0 {
+		t.Errorf("Expected no locales for an empty string, got %v (%v)", locales, err)
+	}
+}
@@ -1,9 +1,19 @@
 package utils
 
 import (
+	"regexp"
+	"strconv"
+	"strings"
+
 	"gorm.io/gorm"
 )
 
+// SlugSequenceTable is the table holding the slug suffix sequences
+const SlugSequenceTable = "slug_sequences"
+
+// slugWriteAttempts bounds how often a write is repeated after losing a race for a slug
+const slugWriteAttempts = 5
+
 // SlugModel defines interface for models that have slug functionality
 type SlugModel interface {
 	GetName() string
@@ -13,23 +23,34 @@ type SlugModel interface {
 	GetTableName() string
 }
 
+// PinnableSlugModel is implemented by models whose slug can be pinned, so that
+// it is kept when the name changes
+type PinnableSlugModel interface {
+	IsSlugPinned() bool
+}
+
 // GenerateModelSlug generates slug for any model that implements SlugModel interface
 func GenerateModelSlug(model SlugModel, tx *gorm.DB) error {
+	// A pinned slug is only generated when missing
+	if pinnable, ok := model.(PinnableSlugModel); ok && pinnable.IsSlugPinned() && model.GetSlug() != "" {
+		return nil
+	}
+
 	// Only generate slug if it's empty or if name has changed
 	if model.GetSlug() == "" || ShouldRegenerateModelSlug(model, tx) {
 		baseSlug := GenerateSlug(model.GetName())
 		if baseSlug == "" {
-			return nil // Skip if name is empty
+			if strings.TrimSpace(model.GetName()) == "" {
+				return nil // Skip if name is empty
+			}
+			// Nothing in the name could be transliterated
+			baseSlug = FallbackSlug(model.GetName())
 		}
 
-		// Check for existing slugs to ensure uniqueness
-		existingSlugs, err := getExistingSlugs(model, tx)
+		uniqueSlug, err := uniqueModelSlug(model, tx, baseSlug)
 		if err != nil {
 			return err
 		}
-
-		// Generate unique slug
-		uniqueSlug := GenerateUniqueSlug(baseSlug, existingSlugs)
 		model.SetSlug(uniqueSlug)
 	}
 
@@ -59,25 +80,106 @@ func ShouldRegenerateModelSlug(model SlugModel, tx *gorm.DB) bool {
 	return result.Name != model.GetName()
 }
 
-// getExistingSlugs retrieves existing slugs for uniqueness check
-func getExistingSlugs(model SlugModel, tx *gorm.DB) ([]string, error) {
-	var results []struct {
-		Slug string `json:"slug"`
+// RetryOnSlugConflict runs write again when it fails with an error isConflict
+// recognizes as a concurrent write having saved the same slug first, which
+// depends on the database driver. A failed statement aborts the surrounding
+// transaction, so write must run its own transaction; the model hooks pick a new
+// slug on the next attempt.
+func RetryOnSlugConflict(isConflict func(error) bool, write func() error) error {
+	var err error
+	for attempt := 0; attempt < slugWriteAttempts; attempt++ {
+		if err = write(); err == nil || !isConflict(err) {
+			return err
+		}
 	}
+	return err
+}
 
-	query := tx.Table(model.GetTableName()).Select("slug")
+// uniqueModelSlug picks a slug based on base that no other record of the table
+// uses. New records take their suffix from the sequence of the base slug, which
+// also keeps the records of a batch insert apart before any of them is saved.
+func uniqueModelSlug(model SlugModel, tx *gorm.DB, base string) (string, error) {
+	// A renamed record gets the plain slug back when nobody else uses it
 	if model.GetID() != 0 {
-		query = query.Where("id != ?", model.GetID())
+		taken, err := slugTaken(model, tx, base)
+		if err != nil || !taken {
+			return base, err
+		}
 	}
 
-	if err := query.Find(&results).Error; err != nil {
-		return nil, err
+	for {
+		suffix, err := nextSlugSuffix(model, tx, base)
+		if err != nil {
+			return "", err
+		}
+
+		candidate := base
+		if suffix > 0 {
+			candidate = base + "-" + strconv.FormatInt(suffix, 10)
+		}
+		// Slugs of other names, e.g. "laptop-2" for "Laptop 2", are skipped
+		taken, err := slugTaken(model, tx, candidate)
+		if err != nil || !taken {
+			return candidate, err
+		}
+	}
+}
+
+// slugTaken reports whether another record of the table, deleted or not, uses slug
+func slugTaken(model SlugModel, tx *gorm.DB, slug string) (bool, error) {
+	var count int64
+	query := tx.Table(model.GetTableName()).Where("slug = ?", slug)
+	if model.GetID() != 0 {
+		query = query.Where("id <> ?", model.GetID())
+	}
+	if err := query.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// nextSlugSuffix advances the sequence of base and returns its new value, where 0
+// stands for base itself. The sequence row stays locked until the transaction
+// ends, so concurrent writers of the same name take turns.
+func nextSlugSuffix(model SlugModel, tx *gorm.DB, base string) (int64, error) {
+	var next int64
+	result := tx.Raw("UPDATE "+SlugSequenceTable+" SET last_suffix = last_suffix + 1 WHERE slug_table = ? AND base = ? RETURNING last_suffix",
+		model.GetTableName(), base).Scan(&next)
+	if result.Error != nil || result.RowsAffected > 0 {
+		return next, result.Error
 	}
 
-	var slugs []string
-	for _, result := range results {
-		slugs = append(slugs, result.Slug)
+	// First use of base: continue after the slugs saved before the sequence existed
+	highest, err := highestSlugSuffix(model, tx, base)
+	if err != nil {
+		return 0, err
+	}
+	err = tx.Raw("INSERT INTO "+SlugSequenceTable+" (slug_table, base, last_suffix) VALUES (?, ?, ?) "+
+		"ON CONFLICT (slug_table, base) DO UPDATE SET last_suffix = "+SlugSequenceTable+".last_suffix + 1 RETURNING last_suffix",
+		model.GetTableName(), base, highest+1).Scan(&next).Error
+	return next, err
+}
+
+// highestSlugSuffix returns the highest numeric suffix used with base, 0 when only
+// base itself is used and -1 when neither is. Only slugs starting with base are
+// read, which the slug pattern index serves without scanning the table.
+func highestSlugSuffix(model SlugModel, tx *gorm.DB, base string) (int64, error) {
+	start := len(base) + 2
+	query := tx.Table(model.GetTableName()).
+		Select("COALESCE(MAX(CASE WHEN slug = ? THEN 0 ELSE CAST(substr(slug, ?) AS bigint) END), -1)", base, start).
+		Where("slug = ? OR (slug LIKE ? AND substr(slug, ?) ~ '^[0-9]{1,18}$')", base, escapeLike(base)+"-%", start)
+	if model.GetID() != 0 {
+		query = query.Where("id <> ?", model.GetID())
 	}
 
-	return slugs, nil
+	var highest int64
+	err := query.Scan(&highest).Error
+	return highest, err
+}
+
+var likeEscaper = regexp.MustCompile(`[\\%_]`)
+
+// escapeLike escapes the LIKE wildcards in s
+func escapeLike(s string) string {
+	return likeEscaper.ReplaceAllString(s, `\$0`)
 }
@@ -403,3 +403,76 @@ func TestSlugModelInterface(t *testing.T) {
 		t.Errorf("Expected slug 'new-slug' after SetSlug, got '%s'", model.GetSlug())
 	}
 }
+
+// PinnedMockSlugModel is a MockSlugModel whose slug can be pinned
+type PinnedMockSlugModel struct {
+	MockSlugModel
+	pinned bool
+}
+
+func (m *PinnedMockSlugModel) IsSlugPinned() bool { return m.pinned }
+
+func TestGenerateModelSlugPinned(t *testing.T) {
+	model := &PinnedMockSlugModel{
+		MockSlugModel: MockSlugModel{id: 1, name: "Renamed Model", slug: "original-model", tableName: "test_models"},
+		pinned:        true,
+	}
+
+	// A pinned slug is kept without looking at the database
+	if err := GenerateModelSlug(model, nil); err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+	if model.GetSlug() != "original-model" {
+		t.Errorf("Expected pinned slug 'original-model', got '%s'", model.GetSlug())
+	}
+
+	var _ PinnableSlugModel = model
+}
+
+func TestRetryOnSlugConflict(t *testing.T) {
+	conflict := errors.New("slug taken")
+	isConflict := func(err error) bool { return errors.Is(err, conflict) }
+
+	t.Run("Retries until the write succeeds", func(t *testing.T) {
+		attempts := 0
+		err := RetryOnSlugConflict(isConflict, func() error {
+			attempts++
+			if attempts < 3 {
+				return conflict
+			}
+			return nil
+		})
+		if err != nil || attempts != 3 {
+			t.Errorf("Expected success after 3 attempts, got %v after %d", err, attempts)
+		}
+	})
+
+	t.Run("Gives up after the last attempt", func(t *testing.T) {
+		attempts := 0
+		err := RetryOnSlugConflict(isConflict, func() error {
+			attempts++
+			return conflict
+		})
+		if !errors.Is(err, conflict) || attempts != slugWriteAttempts {
+			t.Errorf("Expected the conflict after %d attempts, got %v after %d", slugWriteAttempts, err, attempts)
+		}
+	})
+
+	t.Run("Other errors are returned at once", func(t *testing.T) {
+		attempts := 0
+		failure := errors.New("connection lost")
+		err := RetryOnSlugConflict(isConflict, func() error {
+			attempts++
+			return failure
+		})
+		if !errors.Is(err, failure) || attempts != 1 {
+			t.Errorf("Expected the error after 1 attempt, got %v after %d", err, attempts)
+		}
+	})
+}
+
+func TestEscapeLike(t *testing.T) {
+	if result := escapeLike(`a_b%c\d`); result != `a\_b\%c\\d` {
+		t.Errorf("Unexpected escaped pattern '%s'", result)
+	}
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordHashCost is the bcrypt cost used for new password hashes
+const PasswordHashCost = 12
+
+// HashPassword returns the bcrypt hash of a plaintext password
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), PasswordHashCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// VerifyPassword reports whether password matches the stored bcrypt hash.
+// Anything that is not a bcrypt hash never matches.
+func VerifyPassword(hash string, password string) bool {
+	if !IsPasswordHash(hash) {
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// RandomPassword returns a password nobody knows, for accounts that must not log in
+func RandomPassword() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// dummyPasswordHash is a hash of the current cost that no login is checked against for real
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, err := bcrypt.GenerateFromPassword([]byte("dummy password"), PasswordHashCost)
+	if err != nil {
+		panic(err)
+	}
+	return hash
+})
+
+// VerifyDummyPassword compares password against a dummy hash and never matches.
+// A login for an unknown user calls it, so that it takes as long as checking
+// the password of a known user and does not reveal which usernames exist.
+func VerifyDummyPassword(password string) bool {
+	_ = bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(password))
+	return false
+}
+
+// IsPasswordHash reports whether value is already a bcrypt hash rather than a plaintext password
+func IsPasswordHash(value string) bool {
+	_, err := bcrypt.Cost([]byte(value))
+	return err == nil
+}
+
+// NeedsRehash reports whether a stored hash should be regenerated with the current cost
+func NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return true
+	}
+	return cost < PasswordHashCost
+}
@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// TestHashPassword tests hashing and verifying passwords
+func TestHashPassword(t *testing.T) {
+	hash, err := HashPassword("s3cret!")
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	if hash == "s3cret!" {
+		t.Error("Expected hash to differ from the plaintext password")
+	}
+	if !IsPasswordHash(hash) {
+		t.Errorf("Expected '%s' to be recognized as a password hash", hash)
+	}
+	if !VerifyPassword(hash, "s3cret!") {
+		t.Error("Expected correct password to verify")
+	}
+	if VerifyPassword(hash, "wrong") {
+		t.Error("Expected wrong password not to verify")
+	}
+}
+
+// TestVerifyPasswordRejectsPlaintext tests that a plaintext stored value never matches
+func TestVerifyPasswordRejectsPlaintext(t *testing.T) {
+	if VerifyPassword("system123", "system123") {
+		t.Error("Expected plaintext stored password not to verify")
+	}
+	if VerifyPassword("", "") {
+		t.Error("Expected empty stored password not to verify")
+	}
+}
+
+// TestVerifyDummyPassword tests that the dummy check never matches and costs as much as a real one
+func TestVerifyDummyPassword(t *testing.T) {
+	if VerifyDummyPassword("dummy password") {
+		t.Error("Expected the dummy password check never to match")
+	}
+	if cost, err := bcrypt.Cost(dummyPasswordHash()); err != nil || cost != PasswordHashCost {
+		t.Errorf("Expected dummy hash with cost %d, got %d (%v)", PasswordHashCost, cost, err)
+	}
+}
+
+// TestRandomPassword tests that random passwords are long and differ
+func TestRandomPassword(t *testing.T) {
+	first, err := RandomPassword()
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+	second, _ := RandomPassword()
+	if len(first) != 64 || first == second {
+		t.Errorf("Expected two different 64 character passwords, got '%s' and '%s'", first, second)
+	}
+}
+
+// TestIsPasswordHash tests plaintext detection
+func TestIsPasswordHash(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    string
+		expected bool
+	}{
+		{name: "Plaintext password", value: "password123", expected: false},
+		{name: "Empty string", value: "", expected: false},
+		{name: "Looks like a prefix only", value: "$2a$", expected: false},
+		{name: "Bcrypt hash", value: "$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := IsPasswordHash(tc.value); result != tc.expected {
+				t.Errorf("Expected IsPasswordHash(%q) to return %v, but got %v", tc.value, tc.expected, result)
+			}
+		})
+	}
+}
+
+// TestNeedsRehash tests detection of hashes created with a lower cost
+func TestNeedsRehash(t *testing.T) {
+	weak, err := bcrypt.GenerateFromPassword([]byte("pw"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("Expected no error, but got: %v", err)
+	}
+
+	if !NeedsRehash(string(weak)) {
+		t.Error("Expected low-cost hash to need rehashing")
+	}
+	if !NeedsRehash("plaintext") {
+		t.Error("Expected plaintext to need rehashing")
+	}
+}
@@ -1,37 +1,140 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"golang.org/x/text/transform"
 	"golang.org/x/text/unicode/norm"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"unicode"
 )
 
+// DefaultSlugMaxLength is the default and highest slug length. It leaves room in
+// the shortest slug column (120) for the numeric suffix of duplicate names.
+const DefaultSlugMaxLength = 100
+
+// fallbackSlugLength is the number of hex digits in a slug derived from a hash
+const fallbackSlugLength = 12
+
+// maxUntransliteratedShare is the share of letters and digits a name may lose for
+// lack of a transliteration before its slug is given up in favour of the hashed
+// fallback, e.g. the kanji of "東京タワー", which would otherwise leave only "tawaa"
+const maxUntransliteratedShare = 0.25
+
+// SlugOptions configures how slugs are generated
+type SlugOptions struct {
+	// MaxLength cuts longer slugs at the last word boundary that fits; 0 disables the limit
+	MaxLength int
+	// Transliterators rewrite non-Latin scripts into Latin text, in order
+	Transliterators []Transliterator
+}
+
+var (
+	slugOptions     atomic.Pointer[SlugOptions]
+	nonSlugChars    = regexp.MustCompile(`[^a-z0-9]+`)
+	repeatedHyphens = regexp.MustCompile(`-+`)
+)
+
+func init() {
+	SetSlugOptions(DefaultSlugOptions())
+}
+
+// DefaultSlugOptions returns the default length limit with every built-in transliterator
+func DefaultSlugOptions() SlugOptions {
+	return SlugOptions{
+		MaxLength:       DefaultSlugMaxLength,
+		Transliterators: []Transliterator{Cyrillic, Greek, Kana, Hangul, Thai},
+	}
+}
+
+// SetSlugOptions replaces the options used by GenerateSlug
+func SetSlugOptions(options SlugOptions) {
+	slugOptions.Store(&options)
+}
+
 // GenerateSlug creates a URL-friendly slug from the given text
 func GenerateSlug(text string) string {
+	return slugOptions.Load().Slug(text)
+}
+
+// Slug creates a URL-friendly slug from the given text. Text of which more than
+// a quarter of the letters and digits cannot be written in Latin letters or
+// digits, such as Chinese without a transliteration table, yields "".
+func (o SlugOptions) Slug(text string) string {
 	if text == "" {
 		return ""
 	}
 
 	// Convert to lowercase
 	slug := strings.ToLower(text)
+	characters, _ := countSlugCharacters(slug)
+
+	// Spell out other scripts; this has to happen before the accents are
+	// removed, since Thai vowels and kana voicing marks are combining marks too
+	for _, transliterator := range o.Transliterators {
+		slug = transliterator.Transliterate(slug)
+	}
 
 	// Remove accents and normalize unicode
 	t := transform.Chain(norm.NFD, transform.RemoveFunc(isMn), norm.NFC)
 	slug, _, _ = transform.String(t, slug)
 
+	// Give up when too much of the text would silently disappear
+	if _, dropped := countSlugCharacters(slug); float64(dropped) > maxUntransliteratedShare*float64(characters) {
+		return ""
+	}
+
 	// Replace spaces and special characters with hyphens
-	slug = regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(slug, "-")
+	slug = nonSlugChars.ReplaceAllString(slug, "-")
 
 	// Remove leading and trailing hyphens
 	slug = strings.Trim(slug, "-")
 
 	// Replace multiple consecutive hyphens with single hyphen
-	slug = regexp.MustCompile(`-+`).ReplaceAllString(slug, "-")
+	slug = repeatedHyphens.ReplaceAllString(slug, "-")
+
+	return truncateSlug(slug, o.MaxLength)
+}
+
+// truncateSlug cuts slug to at most maxLength characters, at the last hyphen
+// when there is one, so that no word is cut in half
+func truncateSlug(slug string, maxLength int) string {
+	if maxLength <= 0 || len(slug) <= maxLength {
+		return slug
+	}
 
-	return slug
+	// A hyphen right after the limit means the first maxLength characters end with a whole word
+	cut := maxLength
+	if slug[maxLength] != '-' {
+		if i := strings.LastIndexByte(slug[:maxLength], '-'); i > 0 {
+			cut = i
+		}
+	}
+	return strings.TrimRight(slug[:cut], "-")
+}
+
+// FallbackSlug derives a slug from a hash of text, for names that GenerateSlug
+// cannot turn into a slug. The same text always yields the same slug.
+func FallbackSlug(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])[:fallbackSlugLength]
+}
+
+// countSlugCharacters counts the letters and digits of lowercased text, and
+// those of them a slug cannot hold
+func countSlugCharacters(text string) (total int, unsupported int) {
+	for _, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			total++
+			if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+				unsupported++
+			}
+		}
+	}
+	return total, unsupported
 }
 
 // isMn reports whether the rune is a nonspacing mark.
@@ -39,34 +142,30 @@ func isMn(r rune) bool {
 	return unicode.Is(unicode.Mn, r) // Mn: nonspacing marks
 }
 
-// GenerateUniqueSlug creates a unique slug by appending numbers if needed
+// GenerateUniqueSlug creates a unique slug by appending the lowest number that
+// makes it unique among existingSlugs
 func GenerateUniqueSlug(baseSlug string, existingSlugs []string) string {
 	if baseSlug == "" {
 		return ""
 	}
 
+	existing := make(map[string]struct{}, len(existingSlugs))
+	for _, slug := range existingSlugs {
+		existing[slug] = struct{}{}
+	}
+
 	// Check if base slug is unique
-	if !contains(existingSlugs, baseSlug) {
+	if _, ok := existing[baseSlug]; !ok {
 		return baseSlug
 	}
 
-	// If not unique, append numbers until we find a unique one
-	counter := 1
-	for {
+	// At most len(existingSlugs) numbers can be taken, so this always ends
+	for counter := 1; ; counter++ {
 		candidateSlug := baseSlug + "-" + strconv.Itoa(counter)
-
-		if !contains(existingSlugs, candidateSlug) {
+		if _, ok := existing[candidateSlug]; !ok {
 			return candidateSlug
 		}
-		counter++
-
-		// Safety check to prevent infinite loop
-		if counter > 1000 {
-			break
-		}
 	}
-
-	return baseSlug
 }
 
 // contains checks if a slice contains a string
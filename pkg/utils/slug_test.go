@@ -1,6 +1,8 @@
 package utils
 
 import (
+	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -74,6 +76,50 @@ func TestGenerateSlug(t *testing.T) {
 }
 
 // TestGenerateUniqueSlug tests the GenerateUniqueSlug function
+func TestSlugMaxLength(t *testing.T) {
+	testCases := []struct {
+		name      string
+		input     string
+		maxLength int
+		expected  string
+	}{
+		{"Shorter than the limit", "Gaming Laptop", 20, "gaming-laptop"},
+		{"Cut at a word boundary", "Gaming Laptop With Stand", 20, "gaming-laptop-with"},
+		{"Limit right before a hyphen", "Gaming Laptop Stand", 13, "gaming-laptop"},
+		{"Single long word", "Supercalifragilistic", 10, "supercalif"},
+		{"No limit", "Gaming Laptop With Stand", 0, "gaming-laptop-with-stand"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := SlugOptions{MaxLength: tc.maxLength}.Slug(tc.input)
+			if result != tc.expected {
+				t.Errorf("Expected '%s', but got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestGenerateSlugDefaultMaxLength(t *testing.T) {
+	slug := GenerateSlug(strings.Repeat("Щука ", 40))
+	if len(slug) > DefaultSlugMaxLength || strings.HasSuffix(slug, "-") {
+		t.Errorf("Expected a slug of at most %d characters ending with a word, got '%s'", DefaultSlugMaxLength, slug)
+	}
+}
+
+func TestFallbackSlug(t *testing.T) {
+	slug := FallbackSlug("東京")
+	if len(slug) != fallbackSlugLength || GenerateSlug(slug) != slug {
+		t.Errorf("Expected a %d character slug, got '%s'", fallbackSlugLength, slug)
+	}
+	if FallbackSlug("東京") != slug {
+		t.Error("Expected the same slug for the same name")
+	}
+	if FallbackSlug("大阪") == slug {
+		t.Error("Expected different slugs for different names")
+	}
+}
+
 func TestGenerateUniqueSlug(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -111,6 +157,12 @@ func TestGenerateUniqueSlug(t *testing.T) {
 			existingSlugs: []string{},
 			expected:      "new-product",
 		},
+		{
+			name:          "Fills the lowest free number",
+			baseSlug:      "electronics",
+			existingSlugs: []string{"electronics", "electronics-2"},
+			expected:      "electronics-1",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -123,6 +175,17 @@ func TestGenerateUniqueSlug(t *testing.T) {
 	}
 }
 
+func TestGenerateUniqueSlugManyDuplicates(t *testing.T) {
+	existing := []string{"laptop"}
+	for i := 1; i <= 5000; i++ {
+		existing = append(existing, "laptop-"+strconv.Itoa(i))
+	}
+
+	if result := GenerateUniqueSlug("laptop", existing); result != "laptop-5001" {
+		t.Errorf("Expected 'laptop-5001', but got '%s'", result)
+	}
+}
+
 // TestContains tests the contains helper function
 func TestContains(t *testing.T) {
 	testCases := []struct {
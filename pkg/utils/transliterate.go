@@ -0,0 +1,403 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// Transliterator rewrites the characters of the scripts it knows into Latin
+// text and leaves every other character as it is. Input is already lowercased.
+type Transliterator interface {
+	Transliterate(text string) string
+}
+
+// TransliterationTable is a Transliterator that replaces character sequences by
+// Latin text, preferring the longest sequence found in the table
+type TransliterationTable struct {
+	entries map[string]string
+	longest int // length in runes of the longest sequence
+}
+
+// NewTransliterationTable creates a table from sequence/Latin text pairs
+func NewTransliterationTable(entries map[string]string) *TransliterationTable {
+	table := &TransliterationTable{entries: make(map[string]string, len(entries))}
+	for text, latin := range entries {
+		text = strings.ToLower(text)
+		table.entries[text] = latin
+		table.longest = max(table.longest, utf8.RuneCountInString(text))
+	}
+	return table
+}
+
+// LoadTransliterationTable reads a table with one "text<TAB>latin" pair per line.
+// Blank lines and lines starting with # are skipped. Ideographic scripts are
+// usually mapped with a trailing space, e.g. "手机\tshou ji ", so that every
+// word becomes a separate part of the slug.
+func LoadTransliterationTable(r io.Reader) (*TransliterationTable, error) {
+	entries := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		source, latin, ok := strings.Cut(text, "\t")
+		if !ok || source == "" {
+			return nil, fmt.Errorf("line %d: expected text and its transliteration separated by a tab", line)
+		}
+		entries[source] = latin
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return NewTransliterationTable(entries), nil
+}
+
+// LoadTransliterationTableFile reads a table file in the LoadTransliterationTable format
+func LoadTransliterationTableFile(path string) (*TransliterationTable, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	table, err := LoadTransliterationTable(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return table, nil
+}
+
+// Transliterate implements Transliterator
+func (t *TransliterationTable) Transliterate(text string) string {
+	runes := []rune(text)
+	var b strings.Builder
+	for i := 0; i < len(runes); {
+		matched := false
+		for n := min(t.longest, len(runes)-i); n > 0; n-- {
+			if latin, ok := t.entries[string(runes[i:i+n])]; ok {
+				b.WriteString(latin)
+				i += n
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			b.WriteRune(runes[i])
+			i++
+		}
+	}
+	return b.String()
+}
+
+// Cyrillic transliterates Russian, Ukrainian, Belarusian, Bulgarian, Serbian and Macedonian
+var Cyrillic = NewTransliterationTable(map[string]string{
+	"а": "a", "б": "b", "в": "v", "г": "g", "д": "d", "е": "e", "ё": "yo", "ж": "zh",
+	"з": "z", "и": "i", "й": "y", "к": "k", "л": "l", "м": "m", "н": "n", "о": "o",
+	"п": "p", "р": "r", "с": "s", "т": "t", "у": "u", "ф": "f", "х": "kh", "ц": "ts",
+	"ч": "ch", "ш": "sh", "щ": "shch", "ъ": "", "ы": "y", "ь": "", "э": "e", "ю": "yu",
+	"я": "ya", "є": "ye", "і": "i", "ї": "yi", "ґ": "g", "ў": "u", "ђ": "dj", "ј": "j",
+	"љ": "lj", "њ": "nj", "ћ": "c", "џ": "dz", "ѓ": "gj", "ќ": "kj", "ѕ": "dz",
+})
+
+// Greek transliterates modern Greek, following ELOT 743 for single letters
+var Greek = NewTransliterationTable(map[string]string{
+	"α": "a", "β": "v", "γ": "g", "δ": "d", "ε": "e", "ζ": "z", "η": "i", "θ": "th",
+	"ι": "i", "κ": "k", "λ": "l", "μ": "m", "ν": "n", "ξ": "x", "ο": "o", "π": "p",
+	"ρ": "r", "σ": "s", "ς": "s", "τ": "t", "υ": "y", "φ": "f", "χ": "ch", "ψ": "ps",
+	"ω": "o", "ά": "a", "έ": "e", "ή": "i", "ί": "i", "ό": "o", "ύ": "y", "ώ": "o",
+	"ϊ": "i", "ϋ": "y", "ΐ": "i", "ΰ": "y", "ου": "ou", "ού": "ou",
+})
+
+// Kana transliterates Japanese hiragana and katakana in Hepburn romanization.
+// Kanji are left alone; they need a table, see LoadTransliterationTable.
+// Without one, a name that is mostly kanji gets the hashed fallback slug.
+var Kana Transliterator = kanaTransliterator{}
+
+type kanaTransliterator struct{}
+
+var kanaSyllables = map[rune]string{
+	'あ': "a", 'い': "i", 'う': "u", 'え': "e", 'お': "o",
+	'か': "ka", 'き': "ki", 'く': "ku", 'け': "ke", 'こ': "ko",
+	'が': "ga", 'ぎ': "gi", 'ぐ': "gu", 'げ': "ge", 'ご': "go",
+	'さ': "sa", 'し': "shi", 'す': "su", 'せ': "se", 'そ': "so",
+	'ざ': "za", 'じ': "ji", 'ず': "zu", 'ぜ': "ze", 'ぞ': "zo",
+	'た': "ta", 'ち': "chi", 'つ': "tsu", 'て': "te", 'と': "to",
+	'だ': "da", 'ぢ': "ji", 'づ': "zu", 'で': "de", 'ど': "do",
+	'な': "na", 'に': "ni", 'ぬ': "nu", 'ね': "ne", 'の': "no",
+	'は': "ha", 'ひ': "hi", 'ふ': "fu", 'へ': "he", 'ほ': "ho",
+	'ば': "ba", 'び': "bi", 'ぶ': "bu", 'べ': "be", 'ぼ': "bo",
+	'ぱ': "pa", 'ぴ': "pi", 'ぷ': "pu", 'ぺ': "pe", 'ぽ': "po",
+	'ま': "ma", 'み': "mi", 'む': "mu", 'め': "me", 'も': "mo",
+	'や': "ya", 'ゆ': "yu", 'よ': "yo",
+	'ら': "ra", 'り': "ri", 'る': "ru", 'れ': "re", 'ろ': "ro",
+	'わ': "wa", 'ゐ': "i", 'ゑ': "e", 'を': "o", 'ん': "n", 'ゔ': "vu",
+	'ゕ': "ka", 'ゖ': "ke", 'ヷ': "va", 'ヸ': "vi", 'ヹ': "ve", 'ヺ': "vo",
+}
+
+// smallKana are written after a syllable and change its vowel
+var smallKana = map[rune]string{
+	'ゃ': "ya", 'ゅ': "yu", 'ょ': "yo", 'ゎ': "wa",
+	'ぁ': "a", 'ぃ': "i", 'ぅ': "u", 'ぇ': "e", 'ぉ': "o",
+}
+
+// Transliterate implements Transliterator
+func (kanaTransliterator) Transliterate(text string) string {
+	if !strings.ContainsFunc(text, isKana) {
+		return text
+	}
+
+	runes := []rune(text)
+	var b strings.Builder
+	var lastVowel byte
+	geminate := false
+	for i := 0; i < len(runes); i++ {
+		r := toHiragana(runes[i])
+		switch r {
+		case 'っ':
+			// A small tsu doubles the consonant that follows
+			geminate = true
+			continue
+		case 'ー':
+			// The long vowel mark repeats the previous vowel
+			if lastVowel != 0 {
+				b.WriteByte(lastVowel)
+			}
+			continue
+		}
+
+		syllable, ok := kanaSyllables[r]
+		if !ok {
+			if small, isSmall := smallKana[r]; isSmall {
+				syllable = small
+			} else {
+				geminate, lastVowel = false, 0
+				b.WriteRune(runes[i])
+				continue
+			}
+		} else if i+1 < len(runes) {
+			if small, isSmall := smallKana[toHiragana(runes[i+1])]; isSmall {
+				syllable = combineKana(syllable, small)
+				i++
+			}
+		}
+
+		if geminate {
+			geminate = false
+			if strings.HasPrefix(syllable, "ch") {
+				syllable = "t" + syllable
+			} else if !isLatinVowel(syllable[0]) {
+				syllable = syllable[:1] + syllable
+			}
+		}
+		b.WriteString(syllable)
+		if last := syllable[len(syllable)-1]; isLatinVowel(last) {
+			lastVowel = last
+		}
+	}
+	return b.String()
+}
+
+// combineKana joins a syllable with the small kana after it, e.g. き+ゃ → kya,
+// し+ゃ → sha and ふ+ぁ → fa
+func combineKana(syllable, small string) string {
+	stem := syllable[:len(syllable)-1]
+	switch {
+	case len(syllable) == 1:
+		if syllable == "u" && len(small) == 1 {
+			return "w" + small
+		}
+		return syllable + small
+	case small[0] == 'y' && strings.HasSuffix(syllable, "i"):
+		if stem == "sh" || stem == "ch" || stem == "j" {
+			return stem + small[1:]
+		}
+		return stem + small
+	case small[0] == 'y':
+		return syllable + small
+	default:
+		return stem + small
+	}
+}
+
+// toHiragana maps katakana to the matching hiragana
+func toHiragana(r rune) rune {
+	if r >= 'ァ' && r <= 'ヶ' {
+		return r - ('ァ' - 'ぁ')
+	}
+	return r
+}
+
+// isKana reports whether r is a hiragana or katakana character
+func isKana(r rune) bool {
+	return r >= 'ぁ' && r <= 'ヿ'
+}
+
+// isLatinVowel reports whether c is a lowercase Latin vowel
+func isLatinVowel(c byte) bool {
+	return strings.IndexByte("aeiou", c) >= 0
+}
+
+// Hangul transliterates Korean in the Revised Romanization, syllable by
+// syllable and without the sound changes between syllables
+var Hangul Transliterator = hangulTransliterator{}
+
+type hangulTransliterator struct{}
+
+var (
+	hangulInitials = []string{"g", "kk", "n", "d", "tt", "r", "m", "b", "pp", "s", "ss", "", "j", "jj", "ch", "k", "t", "p", "h"}
+	hangulMedials  = []string{"a", "ae", "ya", "yae", "eo", "e", "yeo", "ye", "o", "wa", "wae", "oe", "yo", "u", "wo", "we", "wi", "yu", "eu", "ui", "i"}
+	hangulFinals   = []string{"", "k", "k", "k", "n", "n", "n", "t", "l", "k", "m", "l", "l", "l", "p", "l", "m", "p", "p", "t", "t", "ng", "t", "t", "k", "t", "p", "t"}
+)
+
+// Transliterate implements Transliterator
+func (hangulTransliterator) Transliterate(text string) string {
+	if !strings.ContainsFunc(text, isHangulSyllable) {
+		return text
+	}
+
+	var b strings.Builder
+	for _, r := range text {
+		if !isHangulSyllable(r) {
+			b.WriteRune(r)
+			continue
+		}
+		s := int(r - '가')
+		b.WriteString(hangulInitials[s/588])
+		b.WriteString(hangulMedials[s%588/28])
+		b.WriteString(hangulFinals[s%28])
+	}
+	return b.String()
+}
+
+// isHangulSyllable reports whether r is a precomposed Hangul syllable
+func isHangulSyllable(r rune) bool {
+	return r >= '가' && r <= '힣'
+}
+
+// Thai transliterates Thai close to the Royal Thai General System. Vowels
+// written before their consonant are moved after it and tone marks are dropped;
+// final consonants keep their initial sound.
+var Thai Transliterator = thaiTransliterator{}
+
+type thaiTransliterator struct{}
+
+var thaiLetters = map[rune]string{
+	'ก': "k", 'ข': "kh", 'ฃ': "kh", 'ค': "kh", 'ฅ': "kh", 'ฆ': "kh", 'ง': "ng", 'จ': "ch",
+	'ฉ': "ch", 'ช': "ch", 'ซ': "s", 'ฌ': "ch", 'ญ': "y", 'ฎ': "d", 'ฏ': "t", 'ฐ': "th",
+	'ฑ': "th", 'ฒ': "th", 'ณ': "n", 'ด': "d", 'ต': "t", 'ถ': "th", 'ท': "th", 'ธ': "th",
+	'น': "n", 'บ': "b", 'ป': "p", 'ผ': "ph", 'ฝ': "f", 'พ': "ph", 'ฟ': "f", 'ภ': "ph",
+	'ม': "m", 'ย': "y", 'ร': "r", 'ฤ': "rue", 'ล': "l", 'ฦ': "lue", 'ว': "w", 'ศ': "s",
+	'ษ': "s", 'ส': "s", 'ห': "h", 'ฬ': "l", 'อ': "o", 'ฮ': "h",
+	'ะ': "a", 'ั': "a", 'า': "a", 'ำ': "am", 'ิ': "i", 'ี': "i", 'ึ': "ue", 'ื': "ue",
+	'ุ': "u", 'ู': "u", 'เ': "e", 'แ': "ae", 'โ': "o", 'ใ': "ai", 'ไ': "ai",
+	'๐': "0", '๑': "1", '๒': "2", '๓': "3", '๔': "4", '๕': "5", '๖': "6", '๗': "7", '๘': "8", '๙': "9",
+}
+
+// thaiLeadingVowels lists the vowel spellings that start before the consonant,
+// longest first; the vowel alone is the fallback
+var thaiLeadingVowels = map[rune][]struct{ after, latin string }{
+	'เ': {{"ีย", "ia"}, {"ือ", "uea"}, {"า", "ao"}, {"อ", "oe"}, {"ะ", "e"}, {"็", "e"}, {"", "e"}},
+	'แ': {{"ะ", "ae"}, {"็", "ae"}, {"", "ae"}},
+	'โ': {{"ะ", "o"}, {"", "o"}},
+	'ใ': {{"", "ai"}},
+	'ไ': {{"", "ai"}},
+}
+
+// Transliterate implements Transliterator
+func (thaiTransliterator) Transliterate(text string) string {
+	if !strings.ContainsFunc(text, isThai) {
+		return text
+	}
+
+	// Drop tone marks, which sit between a consonant and its vowel
+	runes := []rune(strings.Map(func(r rune) rune {
+		if r >= '่' && r <= '๋' {
+			return -1
+		}
+		return r
+	}, text))
+
+	var b strings.Builder
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		next := rune(0)
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+
+		switch {
+		case next == '์':
+			// A consonant with the thanthakhat is silent
+			i++
+		case thaiLeadingVowels[r] != nil && isThaiConsonant(next):
+			n := thaiInitialLength(runes[i+1:])
+			writeThaiConsonants(&b, runes[i+1:i+1+n])
+			rest := string(runes[i+1+n:])
+			for _, spelling := range thaiLeadingVowels[r] {
+				if strings.HasPrefix(rest, spelling.after) {
+					b.WriteString(spelling.latin)
+					i += n + utf8.RuneCountInString(spelling.after)
+					break
+				}
+			}
+		case r == 'ั' && next == 'ว':
+			b.WriteString("ua")
+			i++
+		case r == 'อ' && isThaiFollowingVowel(next):
+			// Silent carrier of a vowel at the start of a syllable
+		case r == 'ห' && isThaiSonorant(next):
+			// Silent, only raises the tone of the next consonant
+		default:
+			if latin, ok := thaiLetters[r]; ok {
+				b.WriteString(latin)
+			} else if r != '์' {
+				b.WriteRune(r)
+			}
+		}
+	}
+	return b.String()
+}
+
+// thaiInitialLength returns how many consonants at the start of runes form the
+// initial of a syllable: one, or two for clusters such as ปล or หม
+func thaiInitialLength(runes []rune) int {
+	if len(runes) > 1 && isThaiConsonant(runes[1]) &&
+		(strings.ContainsRune("กขคตปผพ", runes[0]) && strings.ContainsRune("รลว", runes[1]) ||
+			runes[0] == 'ห' && isThaiSonorant(runes[1])) {
+		return 2
+	}
+	return 1
+}
+
+// writeThaiConsonants writes the initial consonants of a syllable
+func writeThaiConsonants(b *strings.Builder, consonants []rune) {
+	for i, r := range consonants {
+		if r == 'ห' && i+1 < len(consonants) {
+			continue
+		}
+		b.WriteString(thaiLetters[r])
+	}
+}
+
+func isThai(r rune) bool {
+	return r >= 'ก' && r <= '๛'
+}
+
+func isThaiConsonant(r rune) bool {
+	return r >= 'ก' && r <= 'ฮ'
+}
+
+func isThaiSonorant(r rune) bool {
+	return strings.ContainsRune("งญนมยรลว", r)
+}
+
+func isThaiFollowingVowel(r rune) bool {
+	return strings.ContainsRune("ะัาำิีึืุู", r)
+}
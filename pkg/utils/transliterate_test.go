@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateSlugTransliteration(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Russian", "Щётка для обуви", "shchyotka-dlya-obuvi"},
+		{"Ukrainian", "Україна", "ukrayina"},
+		{"Mixed Cyrillic and Latin", "Смартфон Samsung Galaxy", "smartfon-samsung-galaxy"},
+		{"Greek with accents", "Κινητό τηλέφωνο", "kinito-tilefono"},
+		{"Greek diphthong", "Μουσική", "mousiki"},
+		{"Katakana", "カメラ", "kamera"},
+		{"Katakana long vowels", "コーヒー", "koohii"},
+		{"Small tsu doubles the consonant", "ショッピング", "shoppingu"},
+		{"Small tsu before ch", "マッチャ", "matcha"},
+		{"Small vowel", "ウィンドウズ", "windouzu"},
+		{"Hiragana", "ひらがな", "hiragana"},
+		{"Hangul", "김치 냉장고", "gimchi-naengjanggo"},
+		{"Thai", "กาแฟ", "kafae"},
+		{"Thai leading vowel spelling", "เสื้อผ้า", "sueapha"},
+		{"Thai consonant cluster", "เปล่า", "plao"},
+		{"Kanji without a table give up the slug", "東京タワー", ""},
+		{"Chinese without a table", "华为手机", ""},
+		{"A few untransliterated letters are dropped", "Tokyo Tower 東", "tokyo-tower"},
+		{"Script without a transliteration", "مرحبا", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := GenerateSlug(tc.input)
+			if result != tc.expected {
+				t.Errorf("Expected '%s', but got '%s'", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestLoadTransliterationTable(t *testing.T) {
+	input := "# Pinyin for common catalog words\n\n手机\tshou ji \n手\tshou \n机\tji \n"
+	table, err := LoadTransliterationTable(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Expected no error but got: %v", err)
+	}
+
+	options := SlugOptions{Transliterators: []Transliterator{table, Kana}}
+	if result := options.Slug("手机"); result != "shou-ji" {
+		t.Errorf("Expected 'shou-ji', but got '%s'", result)
+	}
+	if result := options.Slug("华为手机"); result != "" {
+		t.Errorf("Expected no slug when half the characters are missing from the table, but got '%s'", result)
+	}
+	if result := options.Slug("手机ケース"); result != "shou-ji-keesu" {
+		t.Errorf("Expected 'shou-ji-keesu', but got '%s'", result)
+	}
+
+	if _, err := LoadTransliterationTable(strings.NewReader("手机 shouji\n")); err == nil {
+		t.Error("Expected an error for a line without a tab")
+	}
+}
+
+func TestTransliterationTableLongestMatch(t *testing.T) {
+	table := NewTransliterationTable(map[string]string{"ab": "x", "a": "y", "B": "z"})
+
+	if result := table.Transliterate("abab-a-b"); result != "xx-y-z" {
+		t.Errorf("Expected 'xx-y-z', but got '%s'", result)
+	}
+}